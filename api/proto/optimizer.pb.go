@@ -22,6 +22,483 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Algorithm int32
+
+const (
+	Algorithm_ALGORITHM_UNSPECIFIED Algorithm = 0 // ГА
+	Algorithm_ALGORITHM_GA          Algorithm = 1
+	Algorithm_ALGORITHM_EXHAUSTIVE  Algorithm = 2 // Полный перебор (не больше 20 терминалов)
+	Algorithm_ALGORITHM_GREEDY_ADD  Algorithm = 3 // Жадное открытие терминалов
+	Algorithm_ALGORITHM_GREEDY_DROP Algorithm = 4 // Жадное закрытие терминалов
+)
+
+// Enum value maps for Algorithm.
+var (
+	Algorithm_name = map[int32]string{
+		0: "ALGORITHM_UNSPECIFIED",
+		1: "ALGORITHM_GA",
+		2: "ALGORITHM_EXHAUSTIVE",
+		3: "ALGORITHM_GREEDY_ADD",
+		4: "ALGORITHM_GREEDY_DROP",
+	}
+	Algorithm_value = map[string]int32{
+		"ALGORITHM_UNSPECIFIED": 0,
+		"ALGORITHM_GA":          1,
+		"ALGORITHM_EXHAUSTIVE":  2,
+		"ALGORITHM_GREEDY_ADD":  3,
+		"ALGORITHM_GREEDY_DROP": 4,
+	}
+)
+
+func (x Algorithm) Enum() *Algorithm {
+	p := new(Algorithm)
+	*p = x
+	return p
+}
+
+func (x Algorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Algorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_optimizer_proto_enumTypes[0].Descriptor()
+}
+
+func (Algorithm) Type() protoreflect.EnumType {
+	return &file_api_proto_optimizer_proto_enumTypes[0]
+}
+
+func (x Algorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Algorithm.Descriptor instead.
+func (Algorithm) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{0}
+}
+
+type ScalingMethod int32
+
+const (
+	ScalingMethod_SCALING_METHOD_UNSPECIFIED ScalingMethod = 0 // Без масштабирования: рулетка — 1/(1+стоимость), ранговая — линейно по рангу
+	ScalingMethod_SCALING_LINEAR             ScalingMethod = 1
+	ScalingMethod_SCALING_SIGMA_TRUNCATION   ScalingMethod = 2
+	ScalingMethod_SCALING_BOLTZMANN          ScalingMethod = 3
+)
+
+// Enum value maps for ScalingMethod.
+var (
+	ScalingMethod_name = map[int32]string{
+		0: "SCALING_METHOD_UNSPECIFIED",
+		1: "SCALING_LINEAR",
+		2: "SCALING_SIGMA_TRUNCATION",
+		3: "SCALING_BOLTZMANN",
+	}
+	ScalingMethod_value = map[string]int32{
+		"SCALING_METHOD_UNSPECIFIED": 0,
+		"SCALING_LINEAR":             1,
+		"SCALING_SIGMA_TRUNCATION":   2,
+		"SCALING_BOLTZMANN":          3,
+	}
+)
+
+func (x ScalingMethod) Enum() *ScalingMethod {
+	p := new(ScalingMethod)
+	*p = x
+	return p
+}
+
+func (x ScalingMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScalingMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_optimizer_proto_enumTypes[1].Descriptor()
+}
+
+func (ScalingMethod) Type() protoreflect.EnumType {
+	return &file_api_proto_optimizer_proto_enumTypes[1]
+}
+
+func (x ScalingMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScalingMethod.Descriptor instead.
+func (ScalingMethod) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{1}
+}
+
+type NichingMethod int32
+
+const (
+	NichingMethod_NICHING_METHOD_UNSPECIFIED NichingMethod = 0 // Без ниш
+	// Селекция по стоимости, умноженной на число похожих особей в радиусе sharing_radius
+	NichingMethod_NICHING_FITNESS_SHARING NichingMethod = 1
+	// Детерминированный crowding: потомок заменяет более похожего на него родителя, если не хуже его
+	NichingMethod_NICHING_CROWDING NichingMethod = 2
+)
+
+// Enum value maps for NichingMethod.
+var (
+	NichingMethod_name = map[int32]string{
+		0: "NICHING_METHOD_UNSPECIFIED",
+		1: "NICHING_FITNESS_SHARING",
+		2: "NICHING_CROWDING",
+	}
+	NichingMethod_value = map[string]int32{
+		"NICHING_METHOD_UNSPECIFIED": 0,
+		"NICHING_FITNESS_SHARING":    1,
+		"NICHING_CROWDING":           2,
+	}
+)
+
+func (x NichingMethod) Enum() *NichingMethod {
+	p := new(NichingMethod)
+	*p = x
+	return p
+}
+
+func (x NichingMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NichingMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_optimizer_proto_enumTypes[2].Descriptor()
+}
+
+func (NichingMethod) Type() protoreflect.EnumType {
+	return &file_api_proto_optimizer_proto_enumTypes[2]
+}
+
+func (x NichingMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NichingMethod.Descriptor instead.
+func (NichingMethod) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{2}
+}
+
+// Условие, остановившее ГА 1-го уровня
+type StopReason int32
+
+const (
+	StopReason_STOP_REASON_UNSPECIFIED StopReason = 0
+	StopReason_STOP_NUM_GENERATIONS    StopReason = 1 // Выполнено num_generations поколений
+	StopReason_STOP_NO_IMPROVEMENT     StopReason = 2 // stopping_criterion поколений подряд без улучшения
+	StopReason_STOP_TARGET_COST        StopReason = 3
+	StopReason_STOP_MIN_IMPROVEMENT    StopReason = 4
+	StopReason_STOP_MIN_DIVERSITY      StopReason = 5
+	StopReason_STOP_MAX_EVALUATIONS    StopReason = 6
+	StopReason_STOP_TIME_LIMIT         StopReason = 7 // Истёк max_duration_ms
+)
+
+// Enum value maps for StopReason.
+var (
+	StopReason_name = map[int32]string{
+		0: "STOP_REASON_UNSPECIFIED",
+		1: "STOP_NUM_GENERATIONS",
+		2: "STOP_NO_IMPROVEMENT",
+		3: "STOP_TARGET_COST",
+		4: "STOP_MIN_IMPROVEMENT",
+		5: "STOP_MIN_DIVERSITY",
+		6: "STOP_MAX_EVALUATIONS",
+		7: "STOP_TIME_LIMIT",
+	}
+	StopReason_value = map[string]int32{
+		"STOP_REASON_UNSPECIFIED": 0,
+		"STOP_NUM_GENERATIONS":    1,
+		"STOP_NO_IMPROVEMENT":     2,
+		"STOP_TARGET_COST":        3,
+		"STOP_MIN_IMPROVEMENT":    4,
+		"STOP_MIN_DIVERSITY":      5,
+		"STOP_MAX_EVALUATIONS":    6,
+		"STOP_TIME_LIMIT":         7,
+	}
+)
+
+func (x StopReason) Enum() *StopReason {
+	p := new(StopReason)
+	*p = x
+	return p
+}
+
+func (x StopReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StopReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_optimizer_proto_enumTypes[3].Descriptor()
+}
+
+func (StopReason) Type() protoreflect.EnumType {
+	return &file_api_proto_optimizer_proto_enumTypes[3]
+}
+
+func (x StopReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StopReason.Descriptor instead.
+func (StopReason) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{3}
+}
+
+type InitStrategy int32
+
+const (
+	InitStrategy_INIT_STRATEGY_UNSPECIFIED InitStrategy = 0
+	InitStrategy_INIT_RANDOM               InitStrategy = 1 // Случайные маски с плотностью density
+	InitStrategy_INIT_GREEDY               InitStrategy = 2 // Решения жадного добавления и удаления, остальные — случайные маски
+	InitStrategy_INIT_DEMAND_WEIGHTED      InitStrategy = 3 // Терминал открыт с вероятностью, пропорциональной спросу получателей, для которых он ближайший
+	InitStrategy_INIT_WARM_START           InitStrategy = 4 // Заданные наборы терминалов, остальные особи — их копии с мутацией
+)
+
+// Enum value maps for InitStrategy.
+var (
+	InitStrategy_name = map[int32]string{
+		0: "INIT_STRATEGY_UNSPECIFIED",
+		1: "INIT_RANDOM",
+		2: "INIT_GREEDY",
+		3: "INIT_DEMAND_WEIGHTED",
+		4: "INIT_WARM_START",
+	}
+	InitStrategy_value = map[string]int32{
+		"INIT_STRATEGY_UNSPECIFIED": 0,
+		"INIT_RANDOM":               1,
+		"INIT_GREEDY":               2,
+		"INIT_DEMAND_WEIGHTED":      3,
+		"INIT_WARM_START":           4,
+	}
+)
+
+func (x InitStrategy) Enum() *InitStrategy {
+	p := new(InitStrategy)
+	*p = x
+	return p
+}
+
+func (x InitStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InitStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_optimizer_proto_enumTypes[4].Descriptor()
+}
+
+func (InitStrategy) Type() protoreflect.EnumType {
+	return &file_api_proto_optimizer_proto_enumTypes[4]
+}
+
+func (x InitStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InitStrategy.Descriptor instead.
+func (InitStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{4}
+}
+
+type LocalSearchStrategy int32
+
+const (
+	LocalSearchStrategy_LOCAL_SEARCH_STRATEGY_UNSPECIFIED LocalSearchStrategy = 0
+	LocalSearchStrategy_LOCAL_SEARCH_FIRST_IMPROVEMENT    LocalSearchStrategy = 1 // Первый найденный улучшающий ход
+	LocalSearchStrategy_LOCAL_SEARCH_BEST_IMPROVEMENT     LocalSearchStrategy = 2 // Лучший ход среди всех соседей
+)
+
+// Enum value maps for LocalSearchStrategy.
+var (
+	LocalSearchStrategy_name = map[int32]string{
+		0: "LOCAL_SEARCH_STRATEGY_UNSPECIFIED",
+		1: "LOCAL_SEARCH_FIRST_IMPROVEMENT",
+		2: "LOCAL_SEARCH_BEST_IMPROVEMENT",
+	}
+	LocalSearchStrategy_value = map[string]int32{
+		"LOCAL_SEARCH_STRATEGY_UNSPECIFIED": 0,
+		"LOCAL_SEARCH_FIRST_IMPROVEMENT":    1,
+		"LOCAL_SEARCH_BEST_IMPROVEMENT":     2,
+	}
+)
+
+func (x LocalSearchStrategy) Enum() *LocalSearchStrategy {
+	p := new(LocalSearchStrategy)
+	*p = x
+	return p
+}
+
+func (x LocalSearchStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LocalSearchStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_optimizer_proto_enumTypes[5].Descriptor()
+}
+
+func (LocalSearchStrategy) Type() protoreflect.EnumType {
+	return &file_api_proto_optimizer_proto_enumTypes[5]
+}
+
+func (x LocalSearchStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LocalSearchStrategy.Descriptor instead.
+func (LocalSearchStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{5}
+}
+
+type LocalSearchScope int32
+
+const (
+	LocalSearchScope_LOCAL_SEARCH_SCOPE_UNSPECIFIED LocalSearchScope = 0
+	LocalSearchScope_LOCAL_SEARCH_ELITE             LocalSearchScope = 1 // Элита (max(elite_count, 1) лучших особей) каждого поколения
+	LocalSearchScope_LOCAL_SEARCH_FINAL_BEST        LocalSearchScope = 2 // Только лучшее решение после ГА
+)
+
+// Enum value maps for LocalSearchScope.
+var (
+	LocalSearchScope_name = map[int32]string{
+		0: "LOCAL_SEARCH_SCOPE_UNSPECIFIED",
+		1: "LOCAL_SEARCH_ELITE",
+		2: "LOCAL_SEARCH_FINAL_BEST",
+	}
+	LocalSearchScope_value = map[string]int32{
+		"LOCAL_SEARCH_SCOPE_UNSPECIFIED": 0,
+		"LOCAL_SEARCH_ELITE":             1,
+		"LOCAL_SEARCH_FINAL_BEST":        2,
+	}
+)
+
+func (x LocalSearchScope) Enum() *LocalSearchScope {
+	p := new(LocalSearchScope)
+	*p = x
+	return p
+}
+
+func (x LocalSearchScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LocalSearchScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_optimizer_proto_enumTypes[6].Descriptor()
+}
+
+func (LocalSearchScope) Type() protoreflect.EnumType {
+	return &file_api_proto_optimizer_proto_enumTypes[6]
+}
+
+func (x LocalSearchScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LocalSearchScope.Descriptor instead.
+func (LocalSearchScope) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{6}
+}
+
+type Objective int32
+
+const (
+	Objective_OBJECTIVE_UNSPECIFIED      Objective = 0
+	Objective_OBJECTIVE_TOTAL_COST       Objective = 1 // Общая стоимость (со штрафами)
+	Objective_OBJECTIVE_ACTIVE_TERMINALS Objective = 2 // Число открытых терминалов
+	Objective_OBJECTIVE_AVG_DISTANCE     Objective = 3 // Среднее расстояние от терминала до получателя, км
+)
+
+// Enum value maps for Objective.
+var (
+	Objective_name = map[int32]string{
+		0: "OBJECTIVE_UNSPECIFIED",
+		1: "OBJECTIVE_TOTAL_COST",
+		2: "OBJECTIVE_ACTIVE_TERMINALS",
+		3: "OBJECTIVE_AVG_DISTANCE",
+	}
+	Objective_value = map[string]int32{
+		"OBJECTIVE_UNSPECIFIED":      0,
+		"OBJECTIVE_TOTAL_COST":       1,
+		"OBJECTIVE_ACTIVE_TERMINALS": 2,
+		"OBJECTIVE_AVG_DISTANCE":     3,
+	}
+)
+
+func (x Objective) Enum() *Objective {
+	p := new(Objective)
+	*p = x
+	return p
+}
+
+func (x Objective) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Objective) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_optimizer_proto_enumTypes[7].Descriptor()
+}
+
+func (Objective) Type() protoreflect.EnumType {
+	return &file_api_proto_optimizer_proto_enumTypes[7]
+}
+
+func (x Objective) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Objective.Descriptor instead.
+func (Objective) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{7}
+}
+
+type MigrationTopology int32
+
+const (
+	MigrationTopology_TOPOLOGY_UNSPECIFIED     MigrationTopology = 0
+	MigrationTopology_TOPOLOGY_RING            MigrationTopology = 1 // Остров i отправляет мигрантов острову i+1 (последний — первому)
+	MigrationTopology_TOPOLOGY_FULLY_CONNECTED MigrationTopology = 2 // Каждый остров отправляет мигрантов всем остальным
+)
+
+// Enum value maps for MigrationTopology.
+var (
+	MigrationTopology_name = map[int32]string{
+		0: "TOPOLOGY_UNSPECIFIED",
+		1: "TOPOLOGY_RING",
+		2: "TOPOLOGY_FULLY_CONNECTED",
+	}
+	MigrationTopology_value = map[string]int32{
+		"TOPOLOGY_UNSPECIFIED":     0,
+		"TOPOLOGY_RING":            1,
+		"TOPOLOGY_FULLY_CONNECTED": 2,
+	}
+)
+
+func (x MigrationTopology) Enum() *MigrationTopology {
+	p := new(MigrationTopology)
+	*p = x
+	return p
+}
+
+func (x MigrationTopology) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MigrationTopology) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_optimizer_proto_enumTypes[8].Descriptor()
+}
+
+func (MigrationTopology) Type() protoreflect.EnumType {
+	return &file_api_proto_optimizer_proto_enumTypes[8]
+}
+
+func (x MigrationTopology) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MigrationTopology.Descriptor instead.
+func (MigrationTopology) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{8}
+}
+
 type SelectionType int32
 
 const (
@@ -58,11 +535,11 @@ func (x SelectionType) String() string {
 }
 
 func (SelectionType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_optimizer_proto_enumTypes[0].Descriptor()
+	return file_api_proto_optimizer_proto_enumTypes[9].Descriptor()
 }
 
 func (SelectionType) Type() protoreflect.EnumType {
-	return &file_api_proto_optimizer_proto_enumTypes[0]
+	return &file_api_proto_optimizer_proto_enumTypes[9]
 }
 
 func (x SelectionType) Number() protoreflect.EnumNumber {
@@ -71,7 +548,7 @@ func (x SelectionType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SelectionType.Descriptor instead.
 func (SelectionType) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{0}
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{9}
 }
 
 type CrossoverType int32
@@ -110,11 +587,11 @@ func (x CrossoverType) String() string {
 }
 
 func (CrossoverType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_optimizer_proto_enumTypes[1].Descriptor()
+	return file_api_proto_optimizer_proto_enumTypes[10].Descriptor()
 }
 
 func (CrossoverType) Type() protoreflect.EnumType {
-	return &file_api_proto_optimizer_proto_enumTypes[1]
+	return &file_api_proto_optimizer_proto_enumTypes[10]
 }
 
 func (x CrossoverType) Number() protoreflect.EnumNumber {
@@ -123,15 +600,17 @@ func (x CrossoverType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CrossoverType.Descriptor instead.
 func (CrossoverType) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{10}
 }
 
 type MutationType int32
 
 const (
-	MutationType_MUTATION_UNSPECIFIED MutationType = 0
-	MutationType_MUTATION_INVERSION   MutationType = 1 // Инверсивная
-	MutationType_MUTATION_SWAP        MutationType = 2 // Перестановка
+	MutationType_MUTATION_UNSPECIFIED   MutationType = 0
+	MutationType_MUTATION_INVERSION     MutationType = 1 // Инверсивная
+	MutationType_MUTATION_SWAP          MutationType = 2 // Перестановка
+	MutationType_MUTATION_BIT_FLIP      MutationType = 3 // Побитовая: открыть/закрыть каждый терминал с вероятностью mutation_rate (только 1-й уровень)
+	MutationType_MUTATION_ADD_DROP_SWAP MutationType = 4 // Открыть, закрыть или заменить один терминал (только 1-й уровень)
 )
 
 // Enum value maps for MutationType.
@@ -140,11 +619,15 @@ var (
 		0: "MUTATION_UNSPECIFIED",
 		1: "MUTATION_INVERSION",
 		2: "MUTATION_SWAP",
+		3: "MUTATION_BIT_FLIP",
+		4: "MUTATION_ADD_DROP_SWAP",
 	}
 	MutationType_value = map[string]int32{
-		"MUTATION_UNSPECIFIED": 0,
-		"MUTATION_INVERSION":   1,
-		"MUTATION_SWAP":        2,
+		"MUTATION_UNSPECIFIED":   0,
+		"MUTATION_INVERSION":     1,
+		"MUTATION_SWAP":          2,
+		"MUTATION_BIT_FLIP":      3,
+		"MUTATION_ADD_DROP_SWAP": 4,
 	}
 )
 
@@ -159,11 +642,11 @@ func (x MutationType) String() string {
 }
 
 func (MutationType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_optimizer_proto_enumTypes[2].Descriptor()
+	return file_api_proto_optimizer_proto_enumTypes[11].Descriptor()
 }
 
 func (MutationType) Type() protoreflect.EnumType {
-	return &file_api_proto_optimizer_proto_enumTypes[2]
+	return &file_api_proto_optimizer_proto_enumTypes[11]
 }
 
 func (x MutationType) Number() protoreflect.EnumNumber {
@@ -172,9 +655,13 @@ func (x MutationType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use MutationType.Descriptor instead.
 func (MutationType) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{11}
 }
 
+// Устарело: классы ТС задаются справочником автопарка, маршрут ссылается на класс полем vehicle_id.
+// Перечисление оставлено для совместимости сгенерированных клиентов.
+//
+// Deprecated: Marked as deprecated in api/proto/optimizer.proto.
 type TransportType int32
 
 const (
@@ -217,11 +704,11 @@ func (x TransportType) String() string {
 }
 
 func (TransportType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_optimizer_proto_enumTypes[3].Descriptor()
+	return file_api_proto_optimizer_proto_enumTypes[12].Descriptor()
 }
 
 func (TransportType) Type() protoreflect.EnumType {
-	return &file_api_proto_optimizer_proto_enumTypes[3]
+	return &file_api_proto_optimizer_proto_enumTypes[12]
 }
 
 func (x TransportType) Number() protoreflect.EnumNumber {
@@ -230,7 +717,7 @@ func (x TransportType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TransportType.Descriptor instead.
 func (TransportType) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{12}
 }
 
 type OptimizeRequest struct {
@@ -239,9 +726,23 @@ type OptimizeRequest struct {
 	// Параметры ГА для 1-го уровня (выбор терминалов)
 	GaSettingsLevel_1 *GASettings `protobuf:"bytes,2,opt,name=ga_settings_level_1,json=gaSettingsLevel1,proto3" json:"ga_settings_level_1,omitempty"`
 	// Дни отгрузки (ровно 2)
-	DeliveryDays  []string `protobuf:"bytes,3,rep,name=delivery_days,json=deliveryDays,proto3" json:"delivery_days,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	DeliveryDays []string `protobuf:"bytes,3,rep,name=delivery_days,json=deliveryDays,proto3" json:"delivery_days,omitempty"`
+	// Параметры ГА для 2-го уровня (назначение грузов на терминалы и выбор ТС).
+	// Необязательно: без них грузы назначаются на ближайший терминал.
+	GaSettingsLevel_2 *GASettings `protobuf:"bytes,4,opt,name=ga_settings_level_2,json=gaSettingsLevel2,proto3" json:"ga_settings_level_2,omitempty"`
+	// Ограничения на набор открытых терминалов (города из списка терминалов направления).
+	// Соблюдаются каждым решением ГА, а не штрафами.
+	MustOpen           []string `protobuf:"bytes,5,rep,name=must_open,json=mustOpen,proto3" json:"must_open,omitempty"`                                  // Терминалы, которые должны остаться открытыми
+	MustClose          []string `protobuf:"bytes,6,rep,name=must_close,json=mustClose,proto3" json:"must_close,omitempty"`                               // Терминалы, которые нельзя открывать
+	MinActiveTerminals int32    `protobuf:"varint,7,opt,name=min_active_terminals,json=minActiveTerminals,proto3" json:"min_active_terminals,omitempty"` // Не меньше стольких открытых терминалов (0 — без ограничения)
+	MaxActiveTerminals int32    `protobuf:"varint,8,opt,name=max_active_terminals,json=maxActiveTerminals,proto3" json:"max_active_terminals,omitempty"` // Не больше стольких открытых терминалов (0 — без ограничения)
+	// Алгоритм выбора терминалов (по умолчанию — ГА). Для эталонных алгоритмов ГА 1-го уровня тоже
+	// запускается, и в каждом результате возвращается сравнение с ним (OptimizationResult.baseline).
+	Algorithm Algorithm `protobuf:"varint,9,opt,name=algorithm,proto3,enum=noytech.v1.Algorithm" json:"algorithm,omitempty"`
+	// Добавить в каждый результат ход ГА 1-го уровня по поколениям (OptimizationResult.history)
+	IncludeHistory bool `protobuf:"varint,10,opt,name=include_history,json=includeHistory,proto3" json:"include_history,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *OptimizeRequest) Reset() {
@@ -295,16 +796,93 @@ func (x *OptimizeRequest) GetDeliveryDays() []string {
 	return nil
 }
 
-type GASettings struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	NumGenerations    int32                  `protobuf:"varint,1,opt,name=num_generations,json=numGenerations,proto3" json:"num_generations,omitempty"`                            // Количество поколений
+func (x *OptimizeRequest) GetGaSettingsLevel_2() *GASettings {
+	if x != nil {
+		return x.GaSettingsLevel_2
+	}
+	return nil
+}
+
+func (x *OptimizeRequest) GetMustOpen() []string {
+	if x != nil {
+		return x.MustOpen
+	}
+	return nil
+}
+
+func (x *OptimizeRequest) GetMustClose() []string {
+	if x != nil {
+		return x.MustClose
+	}
+	return nil
+}
+
+func (x *OptimizeRequest) GetMinActiveTerminals() int32 {
+	if x != nil {
+		return x.MinActiveTerminals
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetMaxActiveTerminals() int32 {
+	if x != nil {
+		return x.MaxActiveTerminals
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetAlgorithm() Algorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return Algorithm_ALGORITHM_UNSPECIFIED
+}
+
+func (x *OptimizeRequest) GetIncludeHistory() bool {
+	if x != nil {
+		return x.IncludeHistory
+	}
+	return false
+}
+
+type GASettings struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	NumGenerations    int32                  `protobuf:"varint,1,opt,name=num_generations,json=numGenerations,proto3" json:"num_generations,omitempty"`                            // Количество поколений
 	NumIndividuals    int32                  `protobuf:"varint,2,opt,name=num_individuals,json=numIndividuals,proto3" json:"num_individuals,omitempty"`                            // Количество индивидов (размер популяции)
 	SelectionType     SelectionType          `protobuf:"varint,3,opt,name=selection_type,json=selectionType,proto3,enum=noytech.v1.SelectionType" json:"selection_type,omitempty"` // Тип селекции
 	CrossoverType     CrossoverType          `protobuf:"varint,4,opt,name=crossover_type,json=crossoverType,proto3,enum=noytech.v1.CrossoverType" json:"crossover_type,omitempty"` // Тип скрещивания
 	MutationType      MutationType           `protobuf:"varint,5,opt,name=mutation_type,json=mutationType,proto3,enum=noytech.v1.MutationType" json:"mutation_type,omitempty"`     // Тип мутации
 	StoppingCriterion int32                  `protobuf:"varint,6,opt,name=stopping_criterion,json=stoppingCriterion,proto3" json:"stopping_criterion,omitempty"`                   // Критерий остановки
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// Seed генератора случайных чисел. Задаётся только в ga_settings_level_1 и действует на весь прогон;
+	// если не указан, сервис выбирает его сам и возвращает в OptimizeResponse.seed.
+	Seed       *int64 `protobuf:"varint,7,opt,name=seed,proto3,oneof" json:"seed,omitempty"`
+	EliteCount int32  `protobuf:"varint,8,opt,name=elite_count,json=eliteCount,proto3" json:"elite_count,omitempty"` // Сколько лучших особей переходит в следующее поколение без изменений (0 — без элитизма)
+	// Вероятность мутации от 0 до 1 (по умолчанию 0.1): для MUTATION_BIT_FLIP — вероятность инвертировать
+	// каждый ген, для остальных операторов — вероятность применить оператор к потомку.
+	MutationRate  *float64 `protobuf:"fixed64,9,opt,name=mutation_rate,json=mutationRate,proto3,oneof" json:"mutation_rate,omitempty"`
+	CrossoverRate *float64 `protobuf:"fixed64,10,opt,name=crossover_rate,json=crossoverRate,proto3,oneof" json:"crossover_rate,omitempty"` // Вероятность скрестить пару родителей от 0 до 1 (по умолчанию 1); иначе потомки — копии родителей
+	// Островная модель (только 1-й уровень): несколько популяций по num_individuals особей
+	// эволюционируют одновременно и периодически обмениваются лучшими особями.
+	Islands *IslandSettings `protobuf:"bytes,11,opt,name=islands,proto3" json:"islands,omitempty"`
+	// Многокритериальный режим NSGA-II (только 1-й уровень): две и более цели. Вместо одного решения
+	// на день возвращается фронт Парето — по OptimizationResult на каждое недоминируемое решение.
+	Objectives []Objective `protobuf:"varint,12,rep,packed,name=objectives,proto3,enum=noytech.v1.Objective" json:"objectives,omitempty"`
+	// Локальный поиск поверх ГА (только 1-й уровень, без многокритериального режима)
+	LocalSearch *LocalSearchSettings `protobuf:"bytes,13,opt,name=local_search,json=localSearch,proto3" json:"local_search,omitempty"`
+	// Начальная популяция (только 1-й уровень); если не указана — случайные маски с плотностью 0.3
+	Init *PopulationInit `protobuf:"bytes,14,opt,name=init,proto3" json:"init,omitempty"`
+	// Бюджет времени прогона уровня на каждый день отгрузки, мс (0 — без ограничения). По истечении
+	// возвращается лучшее найденное решение и OptimizationResult.time_limited; начальная популяция
+	// оценивается всегда.
+	MaxDurationMs int32 `protobuf:"varint,15,opt,name=max_duration_ms,json=maxDurationMs,proto3" json:"max_duration_ms,omitempty"`
+	// Дополнительные условия остановки (только 1-й уровень, без многокритериального режима)
+	StopConditions *StopConditions `protobuf:"bytes,16,opt,name=stop_conditions,json=stopConditions,proto3" json:"stop_conditions,omitempty"`
+	// Поддержание разнообразия популяции (только 1-й уровень, без многокритериального режима)
+	Diversity      *DiversitySettings `protobuf:"bytes,17,opt,name=diversity,proto3" json:"diversity,omitempty"`
+	TournamentSize int32              `protobuf:"varint,18,opt,name=tournament_size,json=tournamentSize,proto3" json:"tournament_size,omitempty"` // Участников турнира в SELECTION_TOURNAMENT (по умолчанию 3)
+	Scaling        *FitnessScaling    `protobuf:"bytes,19,opt,name=scaling,proto3" json:"scaling,omitempty"`                                      // Масштабирование весов в SELECTION_ROULETTE и SELECTION_RANK
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GASettings) Reset() {
@@ -351,60 +929,1166 @@ func (x *GASettings) GetNumIndividuals() int32 {
 	return 0
 }
 
-func (x *GASettings) GetSelectionType() SelectionType {
+func (x *GASettings) GetSelectionType() SelectionType {
+	if x != nil {
+		return x.SelectionType
+	}
+	return SelectionType_SELECTION_UNSPECIFIED
+}
+
+func (x *GASettings) GetCrossoverType() CrossoverType {
+	if x != nil {
+		return x.CrossoverType
+	}
+	return CrossoverType_CROSSOVER_UNSPECIFIED
+}
+
+func (x *GASettings) GetMutationType() MutationType {
+	if x != nil {
+		return x.MutationType
+	}
+	return MutationType_MUTATION_UNSPECIFIED
+}
+
+func (x *GASettings) GetStoppingCriterion() int32 {
+	if x != nil {
+		return x.StoppingCriterion
+	}
+	return 0
+}
+
+func (x *GASettings) GetSeed() int64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+func (x *GASettings) GetEliteCount() int32 {
+	if x != nil {
+		return x.EliteCount
+	}
+	return 0
+}
+
+func (x *GASettings) GetMutationRate() float64 {
+	if x != nil && x.MutationRate != nil {
+		return *x.MutationRate
+	}
+	return 0
+}
+
+func (x *GASettings) GetCrossoverRate() float64 {
+	if x != nil && x.CrossoverRate != nil {
+		return *x.CrossoverRate
+	}
+	return 0
+}
+
+func (x *GASettings) GetIslands() *IslandSettings {
+	if x != nil {
+		return x.Islands
+	}
+	return nil
+}
+
+func (x *GASettings) GetObjectives() []Objective {
+	if x != nil {
+		return x.Objectives
+	}
+	return nil
+}
+
+func (x *GASettings) GetLocalSearch() *LocalSearchSettings {
+	if x != nil {
+		return x.LocalSearch
+	}
+	return nil
+}
+
+func (x *GASettings) GetInit() *PopulationInit {
+	if x != nil {
+		return x.Init
+	}
+	return nil
+}
+
+func (x *GASettings) GetMaxDurationMs() int32 {
+	if x != nil {
+		return x.MaxDurationMs
+	}
+	return 0
+}
+
+func (x *GASettings) GetStopConditions() *StopConditions {
+	if x != nil {
+		return x.StopConditions
+	}
+	return nil
+}
+
+func (x *GASettings) GetDiversity() *DiversitySettings {
+	if x != nil {
+		return x.Diversity
+	}
+	return nil
+}
+
+func (x *GASettings) GetTournamentSize() int32 {
+	if x != nil {
+		return x.TournamentSize
+	}
+	return 0
+}
+
+func (x *GASettings) GetScaling() *FitnessScaling {
+	if x != nil {
+		return x.Scaling
+	}
+	return nil
+}
+
+// Веса рулетки считаются по стоимости особей, веса ранговой селекции — по их рангу; масштабирование
+// задаёт, насколько лучшие особи выбираются чаще остальных. Особи без открытых терминалов
+// (стоимость 1e12) в рулетке получают нулевой вес.
+type FitnessScaling struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Method         ScalingMethod          `protobuf:"varint,1,opt,name=method,proto3,enum=noytech.v1.ScalingMethod" json:"method,omitempty"`
+	LinearPressure float64                `protobuf:"fixed64,2,opt,name=linear_pressure,json=linearPressure,proto3" json:"linear_pressure,omitempty"` // SCALING_LINEAR: во сколько раз вес лучшей особи больше среднего (по умолчанию 2)
+	SigmaC         float64                `protobuf:"fixed64,3,opt,name=sigma_c,json=sigmaC,proto3" json:"sigma_c,omitempty"`                         // SCALING_SIGMA_TRUNCATION: вес max(0, g - (среднее - sigma_c·σ)) (по умолчанию 2)
+	Temperature    float64                `protobuf:"fixed64,4,opt,name=temperature,proto3" json:"temperature,omitempty"`                             // SCALING_BOLTZMANN: вес exp((g - лучшее) / (temperature·σ)) (по умолчанию 1)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FitnessScaling) Reset() {
+	*x = FitnessScaling{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FitnessScaling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FitnessScaling) ProtoMessage() {}
+
+func (x *FitnessScaling) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FitnessScaling.ProtoReflect.Descriptor instead.
+func (*FitnessScaling) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FitnessScaling) GetMethod() ScalingMethod {
+	if x != nil {
+		return x.Method
+	}
+	return ScalingMethod_SCALING_METHOD_UNSPECIFIED
+}
+
+func (x *FitnessScaling) GetLinearPressure() float64 {
+	if x != nil {
+		return x.LinearPressure
+	}
+	return 0
+}
+
+func (x *FitnessScaling) GetSigmaC() float64 {
+	if x != nil {
+		return x.SigmaC
+	}
+	return 0
+}
+
+func (x *FitnessScaling) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+type DiversitySettings struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	EliminateDuplicates bool                   `protobuf:"varint,1,opt,name=eliminate_duplicates,json=eliminateDuplicates,proto3" json:"eliminate_duplicates,omitempty"` // Потомок с уже имеющимся в поколении набором терминалов изменяется до уникального
+	Niching             NichingMethod          `protobuf:"varint,2,opt,name=niching,proto3,enum=noytech.v1.NichingMethod" json:"niching,omitempty"`
+	SharingRadius       float64                `protobuf:"fixed64,3,opt,name=sharing_radius,json=sharingRadius,proto3" json:"sharing_radius,omitempty"` // NICHING_FITNESS_SHARING: радиус ниши — доля различающихся терминалов (0..1]
+	// Случайные иммигранты: когда разнообразие популяции (средняя доля различающихся терминалов у пары особей)
+	// ниже immigrant_threshold, доля immigrant_rate худших особей заменяется случайными наборами терминалов
+	ImmigrantRate      float64 `protobuf:"fixed64,4,opt,name=immigrant_rate,json=immigrantRate,proto3" json:"immigrant_rate,omitempty"`
+	ImmigrantThreshold float64 `protobuf:"fixed64,5,opt,name=immigrant_threshold,json=immigrantThreshold,proto3" json:"immigrant_threshold,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DiversitySettings) Reset() {
+	*x = DiversitySettings{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiversitySettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiversitySettings) ProtoMessage() {}
+
+func (x *DiversitySettings) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiversitySettings.ProtoReflect.Descriptor instead.
+func (*DiversitySettings) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DiversitySettings) GetEliminateDuplicates() bool {
+	if x != nil {
+		return x.EliminateDuplicates
+	}
+	return false
+}
+
+func (x *DiversitySettings) GetNiching() NichingMethod {
+	if x != nil {
+		return x.Niching
+	}
+	return NichingMethod_NICHING_METHOD_UNSPECIFIED
+}
+
+func (x *DiversitySettings) GetSharingRadius() float64 {
+	if x != nil {
+		return x.SharingRadius
+	}
+	return 0
+}
+
+func (x *DiversitySettings) GetImmigrantRate() float64 {
+	if x != nil {
+		return x.ImmigrantRate
+	}
+	return 0
+}
+
+func (x *DiversitySettings) GetImmigrantThreshold() float64 {
+	if x != nil {
+		return x.ImmigrantThreshold
+	}
+	return 0
+}
+
+// Условия остановки проверяются после каждого поколения вместе с num_generations, stopping_criterion
+// и max_duration_ms; срабатывает первое выполненное. 0 — условие не проверяется.
+type StopConditions struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TargetCost float64                `protobuf:"fixed64,1,opt,name=target_cost,json=targetCost,proto3" json:"target_cost,omitempty"` // Лучшая стоимость не больше target_cost
+	// Лучшая стоимость за improvement_window поколений снизилась меньше чем на эту долю (например, 0.001 — 0.1%)
+	MinRelativeImprovement float64 `protobuf:"fixed64,2,opt,name=min_relative_improvement,json=minRelativeImprovement,proto3" json:"min_relative_improvement,omitempty"`
+	ImprovementWindow      int32   `protobuf:"varint,3,opt,name=improvement_window,json=improvementWindow,proto3" json:"improvement_window,omitempty"`
+	MinDiversity           float64 `protobuf:"fixed64,4,opt,name=min_diversity,json=minDiversity,proto3" json:"min_diversity,omitempty"`      // Разнообразие популяции (средняя доля различающихся генов у пары особей) ниже порога
+	MaxEvaluations         int64   `protobuf:"varint,5,opt,name=max_evaluations,json=maxEvaluations,proto3" json:"max_evaluations,omitempty"` // Оценок fitness за прогон (включая попадания в кэш и локальный поиск) не меньше
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *StopConditions) Reset() {
+	*x = StopConditions{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopConditions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopConditions) ProtoMessage() {}
+
+func (x *StopConditions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopConditions.ProtoReflect.Descriptor instead.
+func (*StopConditions) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StopConditions) GetTargetCost() float64 {
+	if x != nil {
+		return x.TargetCost
+	}
+	return 0
+}
+
+func (x *StopConditions) GetMinRelativeImprovement() float64 {
+	if x != nil {
+		return x.MinRelativeImprovement
+	}
+	return 0
+}
+
+func (x *StopConditions) GetImprovementWindow() int32 {
+	if x != nil {
+		return x.ImprovementWindow
+	}
+	return 0
+}
+
+func (x *StopConditions) GetMinDiversity() float64 {
+	if x != nil {
+		return x.MinDiversity
+	}
+	return 0
+}
+
+func (x *StopConditions) GetMaxEvaluations() int64 {
+	if x != nil {
+		return x.MaxEvaluations
+	}
+	return 0
+}
+
+type PopulationInit struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Strategy InitStrategy           `protobuf:"varint,1,opt,name=strategy,proto3,enum=noytech.v1.InitStrategy" json:"strategy,omitempty"`
+	// Доля открытых терминалов в случайных масках от 0 до 1 (0 — по умолчанию 0.3).
+	// Для INIT_DEMAND_WEIGHTED — средняя доля, вероятности пропорциональны спросу.
+	Density       float64        `protobuf:"fixed64,2,opt,name=density,proto3" json:"density,omitempty"`
+	SolutionId    string         `protobuf:"bytes,3,opt,name=solution_id,json=solutionId,proto3" json:"solution_id,omitempty"`       // INIT_WARM_START: сохранённое решение, с наборов терминалов которого начать
+	TerminalSets  []*TerminalSet `protobuf:"bytes,4,rep,name=terminal_sets,json=terminalSets,proto3" json:"terminal_sets,omitempty"` // INIT_WARM_START: наборы терминалов, переданные клиентом
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PopulationInit) Reset() {
+	*x = PopulationInit{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PopulationInit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PopulationInit) ProtoMessage() {}
+
+func (x *PopulationInit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PopulationInit.ProtoReflect.Descriptor instead.
+func (*PopulationInit) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PopulationInit) GetStrategy() InitStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return InitStrategy_INIT_STRATEGY_UNSPECIFIED
+}
+
+func (x *PopulationInit) GetDensity() float64 {
+	if x != nil {
+		return x.Density
+	}
+	return 0
+}
+
+func (x *PopulationInit) GetSolutionId() string {
+	if x != nil {
+		return x.SolutionId
+	}
+	return ""
+}
+
+func (x *PopulationInit) GetTerminalSets() []*TerminalSet {
+	if x != nil {
+		return x.TerminalSets
+	}
+	return nil
+}
+
+// Набор открытых терминалов (города)
+type TerminalSet struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActiveTerminals []string               `protobuf:"bytes,1,rep,name=active_terminals,json=activeTerminals,proto3" json:"active_terminals,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TerminalSet) Reset() {
+	*x = TerminalSet{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminalSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminalSet) ProtoMessage() {}
+
+func (x *TerminalSet) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminalSet.ProtoReflect.Descriptor instead.
+func (*TerminalSet) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TerminalSet) GetActiveTerminals() []string {
+	if x != nil {
+		return x.ActiveTerminals
+	}
+	return nil
+}
+
+type LocalSearchSettings struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Strategy       LocalSearchStrategy    `protobuf:"varint,1,opt,name=strategy,proto3,enum=noytech.v1.LocalSearchStrategy" json:"strategy,omitempty"` // Какой улучшающий ход выбирается
+	Scope          LocalSearchScope       `protobuf:"varint,2,opt,name=scope,proto3,enum=noytech.v1.LocalSearchScope" json:"scope,omitempty"`          // К каким особям применяется
+	MaxEvaluations int32                  `protobuf:"varint,3,opt,name=max_evaluations,json=maxEvaluations,proto3" json:"max_evaluations,omitempty"`   // Бюджет оценок fitness на прогон (0 — без ограничения)
+	MaxDurationMs  int32                  `protobuf:"varint,4,opt,name=max_duration_ms,json=maxDurationMs,proto3" json:"max_duration_ms,omitempty"`    // Бюджет времени на прогон, мс (0 — без ограничения)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LocalSearchSettings) Reset() {
+	*x = LocalSearchSettings{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocalSearchSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalSearchSettings) ProtoMessage() {}
+
+func (x *LocalSearchSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalSearchSettings.ProtoReflect.Descriptor instead.
+func (*LocalSearchSettings) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LocalSearchSettings) GetStrategy() LocalSearchStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return LocalSearchStrategy_LOCAL_SEARCH_STRATEGY_UNSPECIFIED
+}
+
+func (x *LocalSearchSettings) GetScope() LocalSearchScope {
+	if x != nil {
+		return x.Scope
+	}
+	return LocalSearchScope_LOCAL_SEARCH_SCOPE_UNSPECIFIED
+}
+
+func (x *LocalSearchSettings) GetMaxEvaluations() int32 {
+	if x != nil {
+		return x.MaxEvaluations
+	}
+	return 0
+}
+
+func (x *LocalSearchSettings) GetMaxDurationMs() int32 {
+	if x != nil {
+		return x.MaxDurationMs
+	}
+	return 0
+}
+
+type IslandSettings struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Count             int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`                                                  // Число островов (не меньше 2)
+	MigrationInterval int32                  `protobuf:"varint,2,opt,name=migration_interval,json=migrationInterval,proto3" json:"migration_interval,omitempty"` // Миграция каждые migration_interval поколений
+	MigrantCount      int32                  `protobuf:"varint,3,opt,name=migrant_count,json=migrantCount,proto3" json:"migrant_count,omitempty"`                // Сколько лучших особей остров отправляет каждому соседу
+	Topology          MigrationTopology      `protobuf:"varint,4,opt,name=topology,proto3,enum=noytech.v1.MigrationTopology" json:"topology,omitempty"`          // Куда отправляются мигранты
+	// Операторы островов: i-й остров берёт operators[i % len(operators)]. Неуказанный оператор
+	// (или пустой список) наследуется из GASettings.
+	Operators     []*IslandOperators `protobuf:"bytes,5,rep,name=operators,proto3" json:"operators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IslandSettings) Reset() {
+	*x = IslandSettings{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IslandSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IslandSettings) ProtoMessage() {}
+
+func (x *IslandSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IslandSettings.ProtoReflect.Descriptor instead.
+func (*IslandSettings) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *IslandSettings) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *IslandSettings) GetMigrationInterval() int32 {
+	if x != nil {
+		return x.MigrationInterval
+	}
+	return 0
+}
+
+func (x *IslandSettings) GetMigrantCount() int32 {
+	if x != nil {
+		return x.MigrantCount
+	}
+	return 0
+}
+
+func (x *IslandSettings) GetTopology() MigrationTopology {
+	if x != nil {
+		return x.Topology
+	}
+	return MigrationTopology_TOPOLOGY_UNSPECIFIED
+}
+
+func (x *IslandSettings) GetOperators() []*IslandOperators {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+type IslandOperators struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SelectionType SelectionType          `protobuf:"varint,1,opt,name=selection_type,json=selectionType,proto3,enum=noytech.v1.SelectionType" json:"selection_type,omitempty"`
+	CrossoverType CrossoverType          `protobuf:"varint,2,opt,name=crossover_type,json=crossoverType,proto3,enum=noytech.v1.CrossoverType" json:"crossover_type,omitempty"`
+	MutationType  MutationType           `protobuf:"varint,3,opt,name=mutation_type,json=mutationType,proto3,enum=noytech.v1.MutationType" json:"mutation_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IslandOperators) Reset() {
+	*x = IslandOperators{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IslandOperators) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IslandOperators) ProtoMessage() {}
+
+func (x *IslandOperators) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IslandOperators.ProtoReflect.Descriptor instead.
+func (*IslandOperators) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *IslandOperators) GetSelectionType() SelectionType {
+	if x != nil {
+		return x.SelectionType
+	}
+	return SelectionType_SELECTION_UNSPECIFIED
+}
+
+func (x *IslandOperators) GetCrossoverType() CrossoverType {
+	if x != nil {
+		return x.CrossoverType
+	}
+	return CrossoverType_CROSSOVER_UNSPECIFIED
+}
+
+func (x *IslandOperators) GetMutationType() MutationType {
+	if x != nil {
+		return x.MutationType
+	}
+	return MutationType_MUTATION_UNSPECIFIED
+}
+
+type OptimizeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Results       []*OptimizationResult  `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"` // Результаты по каждому дню отгрузки (в порядке delivery_days)
+	SolutionId    string                 `protobuf:"bytes,4,opt,name=solution_id,json=solutionId,proto3" json:"solution_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	WeeklyCost    *CostBreakdown         `protobuf:"bytes,6,opt,name=weekly_cost,json=weeklyCost,proto3" json:"weekly_cost,omitempty"` // Суммарная стоимость недельного плана (все дни отгрузки)
+	Seed          int64                  `protobuf:"varint,7,opt,name=seed,proto3" json:"seed,omitempty"`                              // Seed прогона: повторный запрос с ним на тех же данных даёт тот же результат
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptimizeResponse) Reset() {
+	*x = OptimizeResponse{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptimizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizeResponse) ProtoMessage() {}
+
+func (x *OptimizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizeResponse.ProtoReflect.Descriptor instead.
+func (*OptimizeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OptimizeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *OptimizeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *OptimizeResponse) GetResults() []*OptimizationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *OptimizeResponse) GetSolutionId() string {
+	if x != nil {
+		return x.SolutionId
+	}
+	return ""
+}
+
+func (x *OptimizeResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OptimizeResponse) GetWeeklyCost() *CostBreakdown {
+	if x != nil {
+		return x.WeeklyCost
+	}
+	return nil
+}
+
+func (x *OptimizeResponse) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type OptimizationResult struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Routes          []*Route               `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`                                                        // Маршруты (линейхолы)
+	Cost            *CostBreakdown         `protobuf:"bytes,2,opt,name=cost,proto3" json:"cost,omitempty"`                                                            // Общая стоимость
+	ActiveTerminals []string               `protobuf:"bytes,3,rep,name=active_terminals,json=activeTerminals,proto3" json:"active_terminals,omitempty"`               // Активные терминалы (города)
+	Generation      int32                  `protobuf:"varint,4,opt,name=generation,proto3" json:"generation,omitempty"`                                               // Поколение ГА 1-го уровня, на котором найдено решение (0 — начальная популяция)
+	FitnessScore    float64                `protobuf:"fixed64,5,opt,name=fitness_score,json=fitnessScore,proto3" json:"fitness_score,omitempty"`                      // Значение функции пригодности (целевая функция)
+	DeliveryDay     string                 `protobuf:"bytes,6,opt,name=delivery_day,json=deliveryDay,proto3" json:"delivery_day,omitempty"`                           // День отгрузки, к которому относится результат
+	FleetUsage      []*FleetUsage          `protobuf:"bytes,7,rep,name=fleet_usage,json=fleetUsage,proto3" json:"fleet_usage,omitempty"`                              // Использование автопарка по классам ТС
+	Stats           *RunStats              `protobuf:"bytes,8,opt,name=stats,proto3" json:"stats,omitempty"`                                                          // Статистика прогона ГА 1-го уровня
+	Objectives      []*ObjectiveValue      `protobuf:"bytes,9,rep,name=objectives,proto3" json:"objectives,omitempty"`                                                // Значения целей решения (многокритериальный режим)
+	ParetoRank      int32                  `protobuf:"varint,10,opt,name=pareto_rank,json=paretoRank,proto3" json:"pareto_rank,omitempty"`                            // Ранг фронта Парето, 1 — недоминируемые решения (многокритериальный режим)
+	Baseline        *BaselineComparison    `protobuf:"bytes,11,opt,name=baseline,proto3" json:"baseline,omitempty"`                                                   // Сравнение ГА с эталонным алгоритмом (если он выбран в OptimizeRequest.algorithm)
+	TimeLimited     bool                   `protobuf:"varint,12,opt,name=time_limited,json=timeLimited,proto3" json:"time_limited,omitempty"`                         // ГА 1-го или 2-го уровня остановлен по max_duration_ms до сходимости
+	StopReason      StopReason             `protobuf:"varint,13,opt,name=stop_reason,json=stopReason,proto3,enum=noytech.v1.StopReason" json:"stop_reason,omitempty"` // Условие, остановившее ГА 1-го уровня
+	StopGeneration  int32                  `protobuf:"varint,14,opt,name=stop_generation,json=stopGeneration,proto3" json:"stop_generation,omitempty"`                // Поколение ГА 1-го уровня, после которого он остановлен (0 — начальная популяция)
+	History         []*GenerationStats     `protobuf:"bytes,15,rep,name=history,proto3" json:"history,omitempty"`                                                     // Ход ГА 1-го уровня по поколениям (если OptimizeRequest.include_history)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *OptimizationResult) Reset() {
+	*x = OptimizationResult{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptimizationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizationResult) ProtoMessage() {}
+
+func (x *OptimizationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizationResult.ProtoReflect.Descriptor instead.
+func (*OptimizationResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OptimizationResult) GetRoutes() []*Route {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+func (x *OptimizationResult) GetCost() *CostBreakdown {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+func (x *OptimizationResult) GetActiveTerminals() []string {
+	if x != nil {
+		return x.ActiveTerminals
+	}
+	return nil
+}
+
+func (x *OptimizationResult) GetGeneration() int32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *OptimizationResult) GetFitnessScore() float64 {
+	if x != nil {
+		return x.FitnessScore
+	}
+	return 0
+}
+
+func (x *OptimizationResult) GetDeliveryDay() string {
+	if x != nil {
+		return x.DeliveryDay
+	}
+	return ""
+}
+
+func (x *OptimizationResult) GetFleetUsage() []*FleetUsage {
+	if x != nil {
+		return x.FleetUsage
+	}
+	return nil
+}
+
+func (x *OptimizationResult) GetStats() *RunStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *OptimizationResult) GetObjectives() []*ObjectiveValue {
+	if x != nil {
+		return x.Objectives
+	}
+	return nil
+}
+
+func (x *OptimizationResult) GetParetoRank() int32 {
+	if x != nil {
+		return x.ParetoRank
+	}
+	return 0
+}
+
+func (x *OptimizationResult) GetBaseline() *BaselineComparison {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *OptimizationResult) GetTimeLimited() bool {
+	if x != nil {
+		return x.TimeLimited
+	}
+	return false
+}
+
+func (x *OptimizationResult) GetStopReason() StopReason {
+	if x != nil {
+		return x.StopReason
+	}
+	return StopReason_STOP_REASON_UNSPECIFIED
+}
+
+func (x *OptimizationResult) GetStopGeneration() int32 {
+	if x != nil {
+		return x.StopGeneration
+	}
+	return 0
+}
+
+func (x *OptimizationResult) GetHistory() []*GenerationStats {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+// Состояние популяции ГА 1-го уровня после поколения
+type GenerationStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Generation      int32                  `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"`                                  // Номер поколения (0 — начальная популяция)
+	BestFitness     float64                `protobuf:"fixed64,2,opt,name=best_fitness,json=bestFitness,proto3" json:"best_fitness,omitempty"`            // Лучшее значение за прогон к этому поколению
+	MeanFitness     float64                `protobuf:"fixed64,3,opt,name=mean_fitness,json=meanFitness,proto3" json:"mean_fitness,omitempty"`            // Среднее по популяции
+	MedianFitness   float64                `protobuf:"fixed64,4,opt,name=median_fitness,json=medianFitness,proto3" json:"median_fitness,omitempty"`      // Медиана по популяции
+	WorstFitness    float64                `protobuf:"fixed64,5,opt,name=worst_fitness,json=worstFitness,proto3" json:"worst_fitness,omitempty"`         // Худшее в популяции
+	Diversity       float64                `protobuf:"fixed64,6,opt,name=diversity,proto3" json:"diversity,omitempty"`                                   // Средняя доля различающихся терминалов у пары особей (0 — все особи одинаковые)
+	DistinctGenomes int32                  `protobuf:"varint,7,opt,name=distinct_genomes,json=distinctGenomes,proto3" json:"distinct_genomes,omitempty"` // Различных наборов терминалов в популяции
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GenerationStats) Reset() {
+	*x = GenerationStats{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerationStats) ProtoMessage() {}
+
+func (x *GenerationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerationStats.ProtoReflect.Descriptor instead.
+func (*GenerationStats) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GenerationStats) GetGeneration() int32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetBestFitness() float64 {
+	if x != nil {
+		return x.BestFitness
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetMeanFitness() float64 {
+	if x != nil {
+		return x.MeanFitness
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetMedianFitness() float64 {
+	if x != nil {
+		return x.MedianFitness
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetWorstFitness() float64 {
+	if x != nil {
+		return x.WorstFitness
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetDiversity() float64 {
+	if x != nil {
+		return x.Diversity
+	}
+	return 0
+}
+
+func (x *GenerationStats) GetDistinctGenomes() int32 {
+	if x != nil {
+		return x.DistinctGenomes
+	}
+	return 0
+}
+
+// Сравнение на 1-м уровне: значения функции пригодности (CalculateFitness) наборов терминалов,
+// найденных ГА и эталонным алгоритмом.
+type BaselineComparison struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm       Algorithm              `protobuf:"varint,1,opt,name=algorithm,proto3,enum=noytech.v1.Algorithm" json:"algorithm,omitempty"`
+	BaselineFitness float64                `protobuf:"fixed64,2,opt,name=baseline_fitness,json=baselineFitness,proto3" json:"baseline_fitness,omitempty"`
+	GaFitness       float64                `protobuf:"fixed64,3,opt,name=ga_fitness,json=gaFitness,proto3" json:"ga_fitness,omitempty"`
+	GapPercent      float64                `protobuf:"fixed64,4,opt,name=gap_percent,json=gapPercent,proto3" json:"gap_percent,omitempty"` // (ga_fitness - baseline_fitness) / baseline_fitness * 100
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BaselineComparison) Reset() {
+	*x = BaselineComparison{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BaselineComparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BaselineComparison) ProtoMessage() {}
+
+func (x *BaselineComparison) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BaselineComparison.ProtoReflect.Descriptor instead.
+func (*BaselineComparison) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BaselineComparison) GetAlgorithm() Algorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return Algorithm_ALGORITHM_UNSPECIFIED
+}
+
+func (x *BaselineComparison) GetBaselineFitness() float64 {
+	if x != nil {
+		return x.BaselineFitness
+	}
+	return 0
+}
+
+func (x *BaselineComparison) GetGaFitness() float64 {
+	if x != nil {
+		return x.GaFitness
+	}
+	return 0
+}
+
+func (x *BaselineComparison) GetGapPercent() float64 {
+	if x != nil {
+		return x.GapPercent
+	}
+	return 0
+}
+
+type ObjectiveValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Objective     Objective              `protobuf:"varint,1,opt,name=objective,proto3,enum=noytech.v1.Objective" json:"objective,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectiveValue) Reset() {
+	*x = ObjectiveValue{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectiveValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectiveValue) ProtoMessage() {}
+
+func (x *ObjectiveValue) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[14]
 	if x != nil {
-		return x.SelectionType
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return SelectionType_SELECTION_UNSPECIFIED
+	return mi.MessageOf(x)
 }
 
-func (x *GASettings) GetCrossoverType() CrossoverType {
-	if x != nil {
-		return x.CrossoverType
-	}
-	return CrossoverType_CROSSOVER_UNSPECIFIED
+// Deprecated: Use ObjectiveValue.ProtoReflect.Descriptor instead.
+func (*ObjectiveValue) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GASettings) GetMutationType() MutationType {
+func (x *ObjectiveValue) GetObjective() Objective {
 	if x != nil {
-		return x.MutationType
+		return x.Objective
 	}
-	return MutationType_MUTATION_UNSPECIFIED
+	return Objective_OBJECTIVE_UNSPECIFIED
 }
 
-func (x *GASettings) GetStoppingCriterion() int32 {
+func (x *ObjectiveValue) GetValue() float64 {
 	if x != nil {
-		return x.StoppingCriterion
+		return x.Value
 	}
 	return 0
 }
 
-type OptimizeResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Results       []*OptimizationResult  `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
-	SolutionId    string                 `protobuf:"bytes,4,opt,name=solution_id,json=solutionId,proto3" json:"solution_id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type RunStats struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	FitnessCacheHits           int64                  `protobuf:"varint,1,opt,name=fitness_cache_hits,json=fitnessCacheHits,proto3" json:"fitness_cache_hits,omitempty"`                                  // Оценок fitness, взятых из кэша
+	FitnessCacheMisses         int64                  `protobuf:"varint,2,opt,name=fitness_cache_misses,json=fitnessCacheMisses,proto3" json:"fitness_cache_misses,omitempty"`                            // Оценок fitness, посчитанных заново
+	LocalSearchEvaluations     int64                  `protobuf:"varint,3,opt,name=local_search_evaluations,json=localSearchEvaluations,proto3" json:"local_search_evaluations,omitempty"`                // Оценок fitness, потраченных локальным поиском
+	LocalSearchImproved        int32                  `protobuf:"varint,4,opt,name=local_search_improved,json=localSearchImproved,proto3" json:"local_search_improved,omitempty"`                         // Сколько раз локальный поиск улучшил особь
+	LocalSearchImprovement     float64                `protobuf:"fixed64,5,opt,name=local_search_improvement,json=localSearchImprovement,proto3" json:"local_search_improvement,omitempty"`               // Суммарное снижение fitness улучшенных локальным поиском особей
+	LocalSearchBestImprovement float64                `protobuf:"fixed64,6,opt,name=local_search_best_improvement,json=localSearchBestImprovement,proto3" json:"local_search_best_improvement,omitempty"` // Снижение fitness итогового решения 1-го уровня локальным поиском
+	Evaluations                int64                  `protobuf:"varint,7,opt,name=evaluations,proto3" json:"evaluations,omitempty"`                                                                      // Всего оценок fitness, включая попадания в кэш и локальный поиск
+	ElapsedMs                  int64                  `protobuf:"varint,8,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`                                                         // Время прогона ГА 1-го уровня, мс
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
-func (x *OptimizeResponse) Reset() {
-	*x = OptimizeResponse{}
-	mi := &file_api_proto_optimizer_proto_msgTypes[2]
+func (x *RunStats) Reset() {
+	*x = RunStats{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OptimizeResponse) String() string {
+func (x *RunStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OptimizeResponse) ProtoMessage() {}
+func (*RunStats) ProtoMessage() {}
 
-func (x *OptimizeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_optimizer_proto_msgTypes[2]
+func (x *RunStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,72 +2099,93 @@ func (x *OptimizeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OptimizeResponse.ProtoReflect.Descriptor instead.
-func (*OptimizeResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use RunStats.ProtoReflect.Descriptor instead.
+func (*RunStats) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *OptimizeResponse) GetSuccess() bool {
+func (x *RunStats) GetFitnessCacheHits() int64 {
 	if x != nil {
-		return x.Success
+		return x.FitnessCacheHits
 	}
-	return false
+	return 0
 }
 
-func (x *OptimizeResponse) GetMessage() string {
+func (x *RunStats) GetFitnessCacheMisses() int64 {
 	if x != nil {
-		return x.Message
+		return x.FitnessCacheMisses
 	}
-	return ""
+	return 0
 }
 
-func (x *OptimizeResponse) GetResults() []*OptimizationResult {
+func (x *RunStats) GetLocalSearchEvaluations() int64 {
 	if x != nil {
-		return x.Results
+		return x.LocalSearchEvaluations
 	}
-	return nil
+	return 0
 }
 
-func (x *OptimizeResponse) GetSolutionId() string {
+func (x *RunStats) GetLocalSearchImproved() int32 {
 	if x != nil {
-		return x.SolutionId
+		return x.LocalSearchImproved
 	}
-	return ""
+	return 0
 }
 
-func (x *OptimizeResponse) GetCreatedAt() *timestamppb.Timestamp {
+func (x *RunStats) GetLocalSearchImprovement() float64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.LocalSearchImprovement
 	}
-	return nil
+	return 0
 }
 
-type OptimizationResult struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Routes          []*Route               `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`                                          // Маршруты (линейхолы)
-	Cost            *CostBreakdown         `protobuf:"bytes,2,opt,name=cost,proto3" json:"cost,omitempty"`                                              // Общая стоимость
-	ActiveTerminals []string               `protobuf:"bytes,3,rep,name=active_terminals,json=activeTerminals,proto3" json:"active_terminals,omitempty"` // Активные терминалы (города)
-	Generation      int32                  `protobuf:"varint,4,opt,name=generation,proto3" json:"generation,omitempty"`                                 // Поколение, на котором найдено решение
-	FitnessScore    float64                `protobuf:"fixed64,5,opt,name=fitness_score,json=fitnessScore,proto3" json:"fitness_score,omitempty"`        // Значение функции пригодности (целевая функция)
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *RunStats) GetLocalSearchBestImprovement() float64 {
+	if x != nil {
+		return x.LocalSearchBestImprovement
+	}
+	return 0
 }
 
-func (x *OptimizationResult) Reset() {
-	*x = OptimizationResult{}
-	mi := &file_api_proto_optimizer_proto_msgTypes[3]
+func (x *RunStats) GetEvaluations() int64 {
+	if x != nil {
+		return x.Evaluations
+	}
+	return 0
+}
+
+func (x *RunStats) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+type FleetUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"` // Класс ТС из справочника автопарка
+	Used          int32                  `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`                           // Рейсов этим классом в плане дня
+	Limited       bool                   `protobuf:"varint,3,opt,name=limited,proto3" json:"limited,omitempty"`                     // Задано ли ограничение на день (иначе available не используется)
+	Available     int32                  `protobuf:"varint,4,opt,name=available,proto3" json:"available,omitempty"`                 // Доступно ТС на день
+	Shortage      int32                  `protobuf:"varint,5,opt,name=shortage,proto3" json:"shortage,omitempty"`                   // Нехватка ТС (used - available), учтена в штрафах
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FleetUsage) Reset() {
+	*x = FleetUsage{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OptimizationResult) String() string {
+func (x *FleetUsage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OptimizationResult) ProtoMessage() {}
+func (*FleetUsage) ProtoMessage() {}
 
-func (x *OptimizationResult) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_optimizer_proto_msgTypes[3]
+func (x *FleetUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -491,60 +2196,60 @@ func (x *OptimizationResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OptimizationResult.ProtoReflect.Descriptor instead.
-func (*OptimizationResult) Descriptor() ([]byte, []int) {
-	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use FleetUsage.ProtoReflect.Descriptor instead.
+func (*FleetUsage) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *OptimizationResult) GetRoutes() []*Route {
+func (x *FleetUsage) GetVehicleId() string {
 	if x != nil {
-		return x.Routes
+		return x.VehicleId
 	}
-	return nil
+	return ""
 }
 
-func (x *OptimizationResult) GetCost() *CostBreakdown {
+func (x *FleetUsage) GetUsed() int32 {
 	if x != nil {
-		return x.Cost
+		return x.Used
 	}
-	return nil
+	return 0
 }
 
-func (x *OptimizationResult) GetActiveTerminals() []string {
+func (x *FleetUsage) GetLimited() bool {
 	if x != nil {
-		return x.ActiveTerminals
+		return x.Limited
 	}
-	return nil
+	return false
 }
 
-func (x *OptimizationResult) GetGeneration() int32 {
+func (x *FleetUsage) GetAvailable() int32 {
 	if x != nil {
-		return x.Generation
+		return x.Available
 	}
 	return 0
 }
 
-func (x *OptimizationResult) GetFitnessScore() float64 {
+func (x *FleetUsage) GetShortage() int32 {
 	if x != nil {
-		return x.FitnessScore
+		return x.Shortage
 	}
 	return 0
 }
 
 type Route struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FromCity      string                 `protobuf:"bytes,1,opt,name=from_city,json=fromCity,proto3" json:"from_city,omitempty"`                                               // Москва
-	ToTerminal    string                 `protobuf:"bytes,2,opt,name=to_terminal,json=toTerminal,proto3" json:"to_terminal,omitempty"`                                         // Терминал (город)
-	ShipmentIds   []string               `protobuf:"bytes,3,rep,name=shipment_ids,json=shipmentIds,proto3" json:"shipment_ids,omitempty"`                                      // ID грузов, назначенных на этот маршрут
-	Cost          float64                `protobuf:"fixed64,4,opt,name=cost,proto3" json:"cost,omitempty"`                                                                     // Стоимость этого лайнхола
-	TransportUsed TransportType          `protobuf:"varint,5,opt,name=transport_used,json=transportUsed,proto3,enum=noytech.v1.TransportType" json:"transport_used,omitempty"` // Использованный тип ТС
+	FromCity      string                 `protobuf:"bytes,1,opt,name=from_city,json=fromCity,proto3" json:"from_city,omitempty"`          // Москва
+	ToTerminal    string                 `protobuf:"bytes,2,opt,name=to_terminal,json=toTerminal,proto3" json:"to_terminal,omitempty"`    // Терминал (город)
+	ShipmentIds   []string               `protobuf:"bytes,3,rep,name=shipment_ids,json=shipmentIds,proto3" json:"shipment_ids,omitempty"` // ID грузов, назначенных на этот маршрут
+	Cost          float64                `protobuf:"fixed64,4,opt,name=cost,proto3" json:"cost,omitempty"`                                // Стоимость этого рейса
+	VehicleId     string                 `protobuf:"bytes,6,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`       // Класс ТС из справочника автопарка (GET /fleet)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Route) Reset() {
 	*x = Route{}
-	mi := &file_api_proto_optimizer_proto_msgTypes[4]
+	mi := &file_api_proto_optimizer_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -556,7 +2261,7 @@ func (x *Route) String() string {
 func (*Route) ProtoMessage() {}
 
 func (x *Route) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_optimizer_proto_msgTypes[4]
+	mi := &file_api_proto_optimizer_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -569,7 +2274,7 @@ func (x *Route) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Route.ProtoReflect.Descriptor instead.
 func (*Route) Descriptor() ([]byte, []int) {
-	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *Route) GetFromCity() string {
@@ -600,11 +2305,11 @@ func (x *Route) GetCost() float64 {
 	return 0
 }
 
-func (x *Route) GetTransportUsed() TransportType {
+func (x *Route) GetVehicleId() string {
 	if x != nil {
-		return x.TransportUsed
+		return x.VehicleId
 	}
-	return TransportType_TRANSPORT_UNSPECIFIED
+	return ""
 }
 
 type CostBreakdown struct {
@@ -619,7 +2324,7 @@ type CostBreakdown struct {
 
 func (x *CostBreakdown) Reset() {
 	*x = CostBreakdown{}
-	mi := &file_api_proto_optimizer_proto_msgTypes[5]
+	mi := &file_api_proto_optimizer_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -631,7 +2336,7 @@ func (x *CostBreakdown) String() string {
 func (*CostBreakdown) ProtoMessage() {}
 
 func (x *CostBreakdown) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_optimizer_proto_msgTypes[5]
+	mi := &file_api_proto_optimizer_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,7 +2349,7 @@ func (x *CostBreakdown) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CostBreakdown.ProtoReflect.Descriptor instead.
 func (*CostBreakdown) Descriptor() ([]byte, []int) {
-	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *CostBreakdown) GetLinehaulCost() float64 {
@@ -675,16 +2380,217 @@ func (x *CostBreakdown) GetTotalCost() float64 {
 	return 0
 }
 
+type OptimizeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Progress      *GenerationProgress    `protobuf:"bytes,1,opt,name=progress,proto3" json:"progress,omitempty"` // Прогресс очередного поколения
+	Result        *OptimizeResponse      `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`     // Итог оптимизации (только в последнем сообщении)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptimizeEvent) Reset() {
+	*x = OptimizeEvent{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptimizeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizeEvent) ProtoMessage() {}
+
+func (x *OptimizeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizeEvent.ProtoReflect.Descriptor instead.
+func (*OptimizeEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OptimizeEvent) GetProgress() *GenerationProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *OptimizeEvent) GetResult() *OptimizeResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type GenerationProgress struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryDay     string                 `protobuf:"bytes,1,opt,name=delivery_day,json=deliveryDay,proto3" json:"delivery_day,omitempty"`               // День отгрузки, для которого идёт оптимизация
+	DayIndex        int32                  `protobuf:"varint,2,opt,name=day_index,json=dayIndex,proto3" json:"day_index,omitempty"`                       // Порядковый номер дня (с 0)
+	DaysTotal       int32                  `protobuf:"varint,3,opt,name=days_total,json=daysTotal,proto3" json:"days_total,omitempty"`                    // Всего дней в запросе
+	Generation      int32                  `protobuf:"varint,4,opt,name=generation,proto3" json:"generation,omitempty"`                                   // Номер поколения (с 1)
+	NumGenerations  int32                  `protobuf:"varint,5,opt,name=num_generations,json=numGenerations,proto3" json:"num_generations,omitempty"`     // Максимальное число поколений
+	BestFitness     float64                `protobuf:"fixed64,6,opt,name=best_fitness,json=bestFitness,proto3" json:"best_fitness,omitempty"`             // Лучшее значение за весь прогон
+	MeanFitness     float64                `protobuf:"fixed64,7,opt,name=mean_fitness,json=meanFitness,proto3" json:"mean_fitness,omitempty"`             // Среднее по текущей популяции
+	WorstFitness    float64                `protobuf:"fixed64,8,opt,name=worst_fitness,json=worstFitness,proto3" json:"worst_fitness,omitempty"`          // Худшее в текущей популяции
+	NoImprovement   int32                  `protobuf:"varint,9,opt,name=no_improvement,json=noImprovement,proto3" json:"no_improvement,omitempty"`        // Поколений подряд без улучшения
+	ActiveTerminals []string               `protobuf:"bytes,10,rep,name=active_terminals,json=activeTerminals,proto3" json:"active_terminals,omitempty"`  // Активные терминалы лучшего решения
+	MedianFitness   float64                `protobuf:"fixed64,11,opt,name=median_fitness,json=medianFitness,proto3" json:"median_fitness,omitempty"`      // Медиана по текущей популяции
+	Diversity       float64                `protobuf:"fixed64,12,opt,name=diversity,proto3" json:"diversity,omitempty"`                                   // Средняя доля различающихся терминалов у пары особей текущей популяции
+	DistinctGenomes int32                  `protobuf:"varint,13,opt,name=distinct_genomes,json=distinctGenomes,proto3" json:"distinct_genomes,omitempty"` // Различных наборов терминалов в текущей популяции
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GenerationProgress) Reset() {
+	*x = GenerationProgress{}
+	mi := &file_api_proto_optimizer_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerationProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerationProgress) ProtoMessage() {}
+
+func (x *GenerationProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_optimizer_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerationProgress.ProtoReflect.Descriptor instead.
+func (*GenerationProgress) Descriptor() ([]byte, []int) {
+	return file_api_proto_optimizer_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GenerationProgress) GetDeliveryDay() string {
+	if x != nil {
+		return x.DeliveryDay
+	}
+	return ""
+}
+
+func (x *GenerationProgress) GetDayIndex() int32 {
+	if x != nil {
+		return x.DayIndex
+	}
+	return 0
+}
+
+func (x *GenerationProgress) GetDaysTotal() int32 {
+	if x != nil {
+		return x.DaysTotal
+	}
+	return 0
+}
+
+func (x *GenerationProgress) GetGeneration() int32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *GenerationProgress) GetNumGenerations() int32 {
+	if x != nil {
+		return x.NumGenerations
+	}
+	return 0
+}
+
+func (x *GenerationProgress) GetBestFitness() float64 {
+	if x != nil {
+		return x.BestFitness
+	}
+	return 0
+}
+
+func (x *GenerationProgress) GetMeanFitness() float64 {
+	if x != nil {
+		return x.MeanFitness
+	}
+	return 0
+}
+
+func (x *GenerationProgress) GetWorstFitness() float64 {
+	if x != nil {
+		return x.WorstFitness
+	}
+	return 0
+}
+
+func (x *GenerationProgress) GetNoImprovement() int32 {
+	if x != nil {
+		return x.NoImprovement
+	}
+	return 0
+}
+
+func (x *GenerationProgress) GetActiveTerminals() []string {
+	if x != nil {
+		return x.ActiveTerminals
+	}
+	return nil
+}
+
+func (x *GenerationProgress) GetMedianFitness() float64 {
+	if x != nil {
+		return x.MedianFitness
+	}
+	return 0
+}
+
+func (x *GenerationProgress) GetDiversity() float64 {
+	if x != nil {
+		return x.Diversity
+	}
+	return 0
+}
+
+func (x *GenerationProgress) GetDistinctGenomes() int32 {
+	if x != nil {
+		return x.DistinctGenomes
+	}
+	return 0
+}
+
 var File_api_proto_optimizer_proto protoreflect.FileDescriptor
 
 const file_api_proto_optimizer_proto_rawDesc = "" +
 	"\n" +
 	"\x19api/proto/optimizer.proto\x12\n" +
-	"noytech.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9b\x01\n" +
+	"noytech.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe0\x03\n" +
 	"\x0fOptimizeRequest\x12\x1c\n" +
 	"\tdirection\x18\x01 \x01(\tR\tdirection\x12E\n" +
 	"\x13ga_settings_level_1\x18\x02 \x01(\v2\x16.noytech.v1.GASettingsR\x10gaSettingsLevel1\x12#\n" +
-	"\rdelivery_days\x18\x03 \x03(\tR\fdeliveryDays\"\xd0\x02\n" +
+	"\rdelivery_days\x18\x03 \x03(\tR\fdeliveryDays\x12E\n" +
+	"\x13ga_settings_level_2\x18\x04 \x01(\v2\x16.noytech.v1.GASettingsR\x10gaSettingsLevel2\x12\x1b\n" +
+	"\tmust_open\x18\x05 \x03(\tR\bmustOpen\x12\x1d\n" +
+	"\n" +
+	"must_close\x18\x06 \x03(\tR\tmustClose\x120\n" +
+	"\x14min_active_terminals\x18\a \x01(\x05R\x12minActiveTerminals\x120\n" +
+	"\x14max_active_terminals\x18\b \x01(\x05R\x12maxActiveTerminals\x123\n" +
+	"\talgorithm\x18\t \x01(\x0e2\x15.noytech.v1.AlgorithmR\talgorithm\x12'\n" +
+	"\x0finclude_history\x18\n" +
+	" \x01(\bR\x0eincludeHistory\"\xf8\a\n" +
 	"\n" +
 	"GASettings\x12'\n" +
 	"\x0fnum_generations\x18\x01 \x01(\x05R\x0enumGenerations\x12'\n" +
@@ -692,7 +2598,68 @@ const file_api_proto_optimizer_proto_rawDesc = "" +
 	"\x0eselection_type\x18\x03 \x01(\x0e2\x19.noytech.v1.SelectionTypeR\rselectionType\x12@\n" +
 	"\x0ecrossover_type\x18\x04 \x01(\x0e2\x19.noytech.v1.CrossoverTypeR\rcrossoverType\x12=\n" +
 	"\rmutation_type\x18\x05 \x01(\x0e2\x18.noytech.v1.MutationTypeR\fmutationType\x12-\n" +
-	"\x12stopping_criterion\x18\x06 \x01(\x05R\x11stoppingCriterion\"\xdc\x01\n" +
+	"\x12stopping_criterion\x18\x06 \x01(\x05R\x11stoppingCriterion\x12\x17\n" +
+	"\x04seed\x18\a \x01(\x03H\x00R\x04seed\x88\x01\x01\x12\x1f\n" +
+	"\velite_count\x18\b \x01(\x05R\n" +
+	"eliteCount\x12(\n" +
+	"\rmutation_rate\x18\t \x01(\x01H\x01R\fmutationRate\x88\x01\x01\x12*\n" +
+	"\x0ecrossover_rate\x18\n" +
+	" \x01(\x01H\x02R\rcrossoverRate\x88\x01\x01\x124\n" +
+	"\aislands\x18\v \x01(\v2\x1a.noytech.v1.IslandSettingsR\aislands\x125\n" +
+	"\n" +
+	"objectives\x18\f \x03(\x0e2\x15.noytech.v1.ObjectiveR\n" +
+	"objectives\x12B\n" +
+	"\flocal_search\x18\r \x01(\v2\x1f.noytech.v1.LocalSearchSettingsR\vlocalSearch\x12.\n" +
+	"\x04init\x18\x0e \x01(\v2\x1a.noytech.v1.PopulationInitR\x04init\x12&\n" +
+	"\x0fmax_duration_ms\x18\x0f \x01(\x05R\rmaxDurationMs\x12C\n" +
+	"\x0fstop_conditions\x18\x10 \x01(\v2\x1a.noytech.v1.StopConditionsR\x0estopConditions\x12;\n" +
+	"\tdiversity\x18\x11 \x01(\v2\x1d.noytech.v1.DiversitySettingsR\tdiversity\x12'\n" +
+	"\x0ftournament_size\x18\x12 \x01(\x05R\x0etournamentSize\x124\n" +
+	"\ascaling\x18\x13 \x01(\v2\x1a.noytech.v1.FitnessScalingR\ascalingB\a\n" +
+	"\x05_seedB\x10\n" +
+	"\x0e_mutation_rateB\x11\n" +
+	"\x0f_crossover_rate\"\xa7\x01\n" +
+	"\x0eFitnessScaling\x121\n" +
+	"\x06method\x18\x01 \x01(\x0e2\x19.noytech.v1.ScalingMethodR\x06method\x12'\n" +
+	"\x0flinear_pressure\x18\x02 \x01(\x01R\x0elinearPressure\x12\x17\n" +
+	"\asigma_c\x18\x03 \x01(\x01R\x06sigmaC\x12 \n" +
+	"\vtemperature\x18\x04 \x01(\x01R\vtemperature\"\xfa\x01\n" +
+	"\x11DiversitySettings\x121\n" +
+	"\x14eliminate_duplicates\x18\x01 \x01(\bR\x13eliminateDuplicates\x123\n" +
+	"\aniching\x18\x02 \x01(\x0e2\x19.noytech.v1.NichingMethodR\aniching\x12%\n" +
+	"\x0esharing_radius\x18\x03 \x01(\x01R\rsharingRadius\x12%\n" +
+	"\x0eimmigrant_rate\x18\x04 \x01(\x01R\rimmigrantRate\x12/\n" +
+	"\x13immigrant_threshold\x18\x05 \x01(\x01R\x12immigrantThreshold\"\xe8\x01\n" +
+	"\x0eStopConditions\x12\x1f\n" +
+	"\vtarget_cost\x18\x01 \x01(\x01R\n" +
+	"targetCost\x128\n" +
+	"\x18min_relative_improvement\x18\x02 \x01(\x01R\x16minRelativeImprovement\x12-\n" +
+	"\x12improvement_window\x18\x03 \x01(\x05R\x11improvementWindow\x12#\n" +
+	"\rmin_diversity\x18\x04 \x01(\x01R\fminDiversity\x12'\n" +
+	"\x0fmax_evaluations\x18\x05 \x01(\x03R\x0emaxEvaluations\"\xbf\x01\n" +
+	"\x0ePopulationInit\x124\n" +
+	"\bstrategy\x18\x01 \x01(\x0e2\x18.noytech.v1.InitStrategyR\bstrategy\x12\x18\n" +
+	"\adensity\x18\x02 \x01(\x01R\adensity\x12\x1f\n" +
+	"\vsolution_id\x18\x03 \x01(\tR\n" +
+	"solutionId\x12<\n" +
+	"\rterminal_sets\x18\x04 \x03(\v2\x17.noytech.v1.TerminalSetR\fterminalSets\"8\n" +
+	"\vTerminalSet\x12)\n" +
+	"\x10active_terminals\x18\x01 \x03(\tR\x0factiveTerminals\"\xd7\x01\n" +
+	"\x13LocalSearchSettings\x12;\n" +
+	"\bstrategy\x18\x01 \x01(\x0e2\x1f.noytech.v1.LocalSearchStrategyR\bstrategy\x122\n" +
+	"\x05scope\x18\x02 \x01(\x0e2\x1c.noytech.v1.LocalSearchScopeR\x05scope\x12'\n" +
+	"\x0fmax_evaluations\x18\x03 \x01(\x05R\x0emaxEvaluations\x12&\n" +
+	"\x0fmax_duration_ms\x18\x04 \x01(\x05R\rmaxDurationMs\"\xf0\x01\n" +
+	"\x0eIslandSettings\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12-\n" +
+	"\x12migration_interval\x18\x02 \x01(\x05R\x11migrationInterval\x12#\n" +
+	"\rmigrant_count\x18\x03 \x01(\x05R\fmigrantCount\x129\n" +
+	"\btopology\x18\x04 \x01(\x0e2\x1d.noytech.v1.MigrationTopologyR\btopology\x129\n" +
+	"\toperators\x18\x05 \x03(\v2\x1b.noytech.v1.IslandOperatorsR\toperators\"\xd4\x01\n" +
+	"\x0fIslandOperators\x12@\n" +
+	"\x0eselection_type\x18\x01 \x01(\x0e2\x19.noytech.v1.SelectionTypeR\rselectionType\x12@\n" +
+	"\x0ecrossover_type\x18\x02 \x01(\x0e2\x19.noytech.v1.CrossoverTypeR\rcrossoverType\x12=\n" +
+	"\rmutation_type\x18\x03 \x01(\x0e2\x18.noytech.v1.MutationTypeR\fmutationType\"\xac\x02\n" +
 	"\x10OptimizeResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x128\n" +
@@ -700,7 +2667,10 @@ const file_api_proto_optimizer_proto_rawDesc = "" +
 	"\vsolution_id\x18\x04 \x01(\tR\n" +
 	"solutionId\x129\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xde\x01\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12:\n" +
+	"\vweekly_cost\x18\x06 \x01(\v2\x19.noytech.v1.CostBreakdownR\n" +
+	"weeklyCost\x12\x12\n" +
+	"\x04seed\x18\a \x01(\x03R\x04seed\"\xbb\x05\n" +
 	"\x12OptimizationResult\x12)\n" +
 	"\x06routes\x18\x01 \x03(\v2\x11.noytech.v1.RouteR\x06routes\x12-\n" +
 	"\x04cost\x18\x02 \x01(\v2\x19.noytech.v1.CostBreakdownR\x04cost\x12)\n" +
@@ -708,20 +2678,144 @@ const file_api_proto_optimizer_proto_rawDesc = "" +
 	"\n" +
 	"generation\x18\x04 \x01(\x05R\n" +
 	"generation\x12#\n" +
-	"\rfitness_score\x18\x05 \x01(\x01R\ffitnessScore\"\xbe\x01\n" +
+	"\rfitness_score\x18\x05 \x01(\x01R\ffitnessScore\x12!\n" +
+	"\fdelivery_day\x18\x06 \x01(\tR\vdeliveryDay\x127\n" +
+	"\vfleet_usage\x18\a \x03(\v2\x16.noytech.v1.FleetUsageR\n" +
+	"fleetUsage\x12*\n" +
+	"\x05stats\x18\b \x01(\v2\x14.noytech.v1.RunStatsR\x05stats\x12:\n" +
+	"\n" +
+	"objectives\x18\t \x03(\v2\x1a.noytech.v1.ObjectiveValueR\n" +
+	"objectives\x12\x1f\n" +
+	"\vpareto_rank\x18\n" +
+	" \x01(\x05R\n" +
+	"paretoRank\x12:\n" +
+	"\bbaseline\x18\v \x01(\v2\x1e.noytech.v1.BaselineComparisonR\bbaseline\x12!\n" +
+	"\ftime_limited\x18\f \x01(\bR\vtimeLimited\x127\n" +
+	"\vstop_reason\x18\r \x01(\x0e2\x16.noytech.v1.StopReasonR\n" +
+	"stopReason\x12'\n" +
+	"\x0fstop_generation\x18\x0e \x01(\x05R\x0estopGeneration\x125\n" +
+	"\ahistory\x18\x0f \x03(\v2\x1b.noytech.v1.GenerationStatsR\ahistory\"\x8c\x02\n" +
+	"\x0fGenerationStats\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x01 \x01(\x05R\n" +
+	"generation\x12!\n" +
+	"\fbest_fitness\x18\x02 \x01(\x01R\vbestFitness\x12!\n" +
+	"\fmean_fitness\x18\x03 \x01(\x01R\vmeanFitness\x12%\n" +
+	"\x0emedian_fitness\x18\x04 \x01(\x01R\rmedianFitness\x12#\n" +
+	"\rworst_fitness\x18\x05 \x01(\x01R\fworstFitness\x12\x1c\n" +
+	"\tdiversity\x18\x06 \x01(\x01R\tdiversity\x12)\n" +
+	"\x10distinct_genomes\x18\a \x01(\x05R\x0fdistinctGenomes\"\xb4\x01\n" +
+	"\x12BaselineComparison\x123\n" +
+	"\talgorithm\x18\x01 \x01(\x0e2\x15.noytech.v1.AlgorithmR\talgorithm\x12)\n" +
+	"\x10baseline_fitness\x18\x02 \x01(\x01R\x0fbaselineFitness\x12\x1d\n" +
+	"\n" +
+	"ga_fitness\x18\x03 \x01(\x01R\tgaFitness\x12\x1f\n" +
+	"\vgap_percent\x18\x04 \x01(\x01R\n" +
+	"gapPercent\"[\n" +
+	"\x0eObjectiveValue\x123\n" +
+	"\tobjective\x18\x01 \x01(\x0e2\x15.noytech.v1.ObjectiveR\tobjective\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\"\x96\x03\n" +
+	"\bRunStats\x12,\n" +
+	"\x12fitness_cache_hits\x18\x01 \x01(\x03R\x10fitnessCacheHits\x120\n" +
+	"\x14fitness_cache_misses\x18\x02 \x01(\x03R\x12fitnessCacheMisses\x128\n" +
+	"\x18local_search_evaluations\x18\x03 \x01(\x03R\x16localSearchEvaluations\x122\n" +
+	"\x15local_search_improved\x18\x04 \x01(\x05R\x13localSearchImproved\x128\n" +
+	"\x18local_search_improvement\x18\x05 \x01(\x01R\x16localSearchImprovement\x12A\n" +
+	"\x1dlocal_search_best_improvement\x18\x06 \x01(\x01R\x1alocalSearchBestImprovement\x12 \n" +
+	"\vevaluations\x18\a \x01(\x03R\vevaluations\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\b \x01(\x03R\telapsedMs\"\x93\x01\n" +
+	"\n" +
+	"FleetUsage\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\x12\x12\n" +
+	"\x04used\x18\x02 \x01(\x05R\x04used\x12\x18\n" +
+	"\alimited\x18\x03 \x01(\bR\alimited\x12\x1c\n" +
+	"\tavailable\x18\x04 \x01(\x05R\tavailable\x12\x1a\n" +
+	"\bshortage\x18\x05 \x01(\x05R\bshortage\"\xb1\x01\n" +
 	"\x05Route\x12\x1b\n" +
 	"\tfrom_city\x18\x01 \x01(\tR\bfromCity\x12\x1f\n" +
 	"\vto_terminal\x18\x02 \x01(\tR\n" +
 	"toTerminal\x12!\n" +
 	"\fshipment_ids\x18\x03 \x03(\tR\vshipmentIds\x12\x12\n" +
-	"\x04cost\x18\x04 \x01(\x01R\x04cost\x12@\n" +
-	"\x0etransport_used\x18\x05 \x01(\x0e2\x19.noytech.v1.TransportTypeR\rtransportUsed\"\x9c\x01\n" +
+	"\x04cost\x18\x04 \x01(\x01R\x04cost\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x06 \x01(\tR\tvehicleIdJ\x04\b\x05\x10\x06R\x0etransport_used\"\x9c\x01\n" +
 	"\rCostBreakdown\x12#\n" +
 	"\rlinehaul_cost\x18\x01 \x01(\x01R\flinehaulCost\x12$\n" +
 	"\x0elast_mile_cost\x18\x02 \x01(\x01R\flastMileCost\x12!\n" +
 	"\fpenalty_cost\x18\x03 \x01(\x01R\vpenaltyCost\x12\x1d\n" +
 	"\n" +
-	"total_cost\x18\x04 \x01(\x01R\ttotalCost*p\n" +
+	"total_cost\x18\x04 \x01(\x01R\ttotalCost\"\x81\x01\n" +
+	"\rOptimizeEvent\x12:\n" +
+	"\bprogress\x18\x01 \x01(\v2\x1e.noytech.v1.GenerationProgressR\bprogress\x124\n" +
+	"\x06result\x18\x02 \x01(\v2\x1c.noytech.v1.OptimizeResponseR\x06result\"\xe9\x03\n" +
+	"\x12GenerationProgress\x12!\n" +
+	"\fdelivery_day\x18\x01 \x01(\tR\vdeliveryDay\x12\x1b\n" +
+	"\tday_index\x18\x02 \x01(\x05R\bdayIndex\x12\x1d\n" +
+	"\n" +
+	"days_total\x18\x03 \x01(\x05R\tdaysTotal\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x04 \x01(\x05R\n" +
+	"generation\x12'\n" +
+	"\x0fnum_generations\x18\x05 \x01(\x05R\x0enumGenerations\x12!\n" +
+	"\fbest_fitness\x18\x06 \x01(\x01R\vbestFitness\x12!\n" +
+	"\fmean_fitness\x18\a \x01(\x01R\vmeanFitness\x12#\n" +
+	"\rworst_fitness\x18\b \x01(\x01R\fworstFitness\x12%\n" +
+	"\x0eno_improvement\x18\t \x01(\x05R\rnoImprovement\x12)\n" +
+	"\x10active_terminals\x18\n" +
+	" \x03(\tR\x0factiveTerminals\x12%\n" +
+	"\x0emedian_fitness\x18\v \x01(\x01R\rmedianFitness\x12\x1c\n" +
+	"\tdiversity\x18\f \x01(\x01R\tdiversity\x12)\n" +
+	"\x10distinct_genomes\x18\r \x01(\x05R\x0fdistinctGenomes*\x87\x01\n" +
+	"\tAlgorithm\x12\x19\n" +
+	"\x15ALGORITHM_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fALGORITHM_GA\x10\x01\x12\x18\n" +
+	"\x14ALGORITHM_EXHAUSTIVE\x10\x02\x12\x18\n" +
+	"\x14ALGORITHM_GREEDY_ADD\x10\x03\x12\x19\n" +
+	"\x15ALGORITHM_GREEDY_DROP\x10\x04*x\n" +
+	"\rScalingMethod\x12\x1e\n" +
+	"\x1aSCALING_METHOD_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSCALING_LINEAR\x10\x01\x12\x1c\n" +
+	"\x18SCALING_SIGMA_TRUNCATION\x10\x02\x12\x15\n" +
+	"\x11SCALING_BOLTZMANN\x10\x03*b\n" +
+	"\rNichingMethod\x12\x1e\n" +
+	"\x1aNICHING_METHOD_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17NICHING_FITNESS_SHARING\x10\x01\x12\x14\n" +
+	"\x10NICHING_CROWDING\x10\x02*\xd3\x01\n" +
+	"\n" +
+	"StopReason\x12\x1b\n" +
+	"\x17STOP_REASON_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14STOP_NUM_GENERATIONS\x10\x01\x12\x17\n" +
+	"\x13STOP_NO_IMPROVEMENT\x10\x02\x12\x14\n" +
+	"\x10STOP_TARGET_COST\x10\x03\x12\x18\n" +
+	"\x14STOP_MIN_IMPROVEMENT\x10\x04\x12\x16\n" +
+	"\x12STOP_MIN_DIVERSITY\x10\x05\x12\x18\n" +
+	"\x14STOP_MAX_EVALUATIONS\x10\x06\x12\x13\n" +
+	"\x0fSTOP_TIME_LIMIT\x10\a*~\n" +
+	"\fInitStrategy\x12\x1d\n" +
+	"\x19INIT_STRATEGY_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vINIT_RANDOM\x10\x01\x12\x0f\n" +
+	"\vINIT_GREEDY\x10\x02\x12\x18\n" +
+	"\x14INIT_DEMAND_WEIGHTED\x10\x03\x12\x13\n" +
+	"\x0fINIT_WARM_START\x10\x04*\x83\x01\n" +
+	"\x13LocalSearchStrategy\x12%\n" +
+	"!LOCAL_SEARCH_STRATEGY_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eLOCAL_SEARCH_FIRST_IMPROVEMENT\x10\x01\x12!\n" +
+	"\x1dLOCAL_SEARCH_BEST_IMPROVEMENT\x10\x02*k\n" +
+	"\x10LocalSearchScope\x12\"\n" +
+	"\x1eLOCAL_SEARCH_SCOPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12LOCAL_SEARCH_ELITE\x10\x01\x12\x1b\n" +
+	"\x17LOCAL_SEARCH_FINAL_BEST\x10\x02*|\n" +
+	"\tObjective\x12\x19\n" +
+	"\x15OBJECTIVE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14OBJECTIVE_TOTAL_COST\x10\x01\x12\x1e\n" +
+	"\x1aOBJECTIVE_ACTIVE_TERMINALS\x10\x02\x12\x1a\n" +
+	"\x16OBJECTIVE_AVG_DISTANCE\x10\x03*^\n" +
+	"\x11MigrationTopology\x12\x18\n" +
+	"\x14TOPOLOGY_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rTOPOLOGY_RING\x10\x01\x12\x1c\n" +
+	"\x18TOPOLOGY_FULLY_CONNECTED\x10\x02*p\n" +
 	"\rSelectionType\x12\x19\n" +
 	"\x15SELECTION_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14SELECTION_TOURNAMENT\x10\x01\x12\x16\n" +
@@ -731,20 +2825,23 @@ const file_api_proto_optimizer_proto_rawDesc = "" +
 	"\x15CROSSOVER_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11CROSSOVER_UNIFORM\x10\x01\x12\x1a\n" +
 	"\x16CROSSOVER_SINGLE_POINT\x10\x02\x12\x17\n" +
-	"\x13CROSSOVER_TWO_POINT\x10\x03*S\n" +
+	"\x13CROSSOVER_TWO_POINT\x10\x03*\x86\x01\n" +
 	"\fMutationType\x12\x18\n" +
 	"\x14MUTATION_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12MUTATION_INVERSION\x10\x01\x12\x11\n" +
-	"\rMUTATION_SWAP\x10\x02*\xa1\x01\n" +
+	"\rMUTATION_SWAP\x10\x02\x12\x15\n" +
+	"\x11MUTATION_BIT_FLIP\x10\x03\x12\x1a\n" +
+	"\x16MUTATION_ADD_DROP_SWAP\x10\x04*\xa5\x01\n" +
 	"\rTransportType\x12\x19\n" +
 	"\x15TRANSPORT_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13TRANSPORT_1_5T_10M3\x10\x01\x12\x15\n" +
 	"\x11TRANSPORT_3T_20M3\x10\x02\x12\x15\n" +
 	"\x11TRANSPORT_5T_36M3\x10\x03\x12\x16\n" +
 	"\x12TRANSPORT_10T_45M3\x10\x04\x12\x16\n" +
-	"\x12TRANSPORT_20T_86M3\x10\x052Y\n" +
+	"\x12TRANSPORT_20T_86M3\x10\x05\x1a\x02\x18\x012\xa5\x01\n" +
 	"\x10OptimizerService\x12E\n" +
-	"\bOptimize\x12\x1b.noytech.v1.OptimizeRequest\x1a\x1c.noytech.v1.OptimizeResponseB Z\x1enoytech-ga-optimizer/api/protob\x06proto3"
+	"\bOptimize\x12\x1b.noytech.v1.OptimizeRequest\x1a\x1c.noytech.v1.OptimizeResponse\x12J\n" +
+	"\x0eOptimizeStream\x12\x1b.noytech.v1.OptimizeRequest\x1a\x19.noytech.v1.OptimizeEvent0\x01B Z\x1enoytech-ga-optimizer/api/protob\x06proto3"
 
 var (
 	file_api_proto_optimizer_proto_rawDescOnce sync.Once
@@ -758,38 +2855,94 @@ func file_api_proto_optimizer_proto_rawDescGZIP() []byte {
 	return file_api_proto_optimizer_proto_rawDescData
 }
 
-var file_api_proto_optimizer_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_api_proto_optimizer_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_proto_optimizer_proto_enumTypes = make([]protoimpl.EnumInfo, 13)
+var file_api_proto_optimizer_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_api_proto_optimizer_proto_goTypes = []any{
-	(SelectionType)(0),            // 0: noytech.v1.SelectionType
-	(CrossoverType)(0),            // 1: noytech.v1.CrossoverType
-	(MutationType)(0),             // 2: noytech.v1.MutationType
-	(TransportType)(0),            // 3: noytech.v1.TransportType
-	(*OptimizeRequest)(nil),       // 4: noytech.v1.OptimizeRequest
-	(*GASettings)(nil),            // 5: noytech.v1.GASettings
-	(*OptimizeResponse)(nil),      // 6: noytech.v1.OptimizeResponse
-	(*OptimizationResult)(nil),    // 7: noytech.v1.OptimizationResult
-	(*Route)(nil),                 // 8: noytech.v1.Route
-	(*CostBreakdown)(nil),         // 9: noytech.v1.CostBreakdown
-	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(Algorithm)(0),                // 0: noytech.v1.Algorithm
+	(ScalingMethod)(0),            // 1: noytech.v1.ScalingMethod
+	(NichingMethod)(0),            // 2: noytech.v1.NichingMethod
+	(StopReason)(0),               // 3: noytech.v1.StopReason
+	(InitStrategy)(0),             // 4: noytech.v1.InitStrategy
+	(LocalSearchStrategy)(0),      // 5: noytech.v1.LocalSearchStrategy
+	(LocalSearchScope)(0),         // 6: noytech.v1.LocalSearchScope
+	(Objective)(0),                // 7: noytech.v1.Objective
+	(MigrationTopology)(0),        // 8: noytech.v1.MigrationTopology
+	(SelectionType)(0),            // 9: noytech.v1.SelectionType
+	(CrossoverType)(0),            // 10: noytech.v1.CrossoverType
+	(MutationType)(0),             // 11: noytech.v1.MutationType
+	(TransportType)(0),            // 12: noytech.v1.TransportType
+	(*OptimizeRequest)(nil),       // 13: noytech.v1.OptimizeRequest
+	(*GASettings)(nil),            // 14: noytech.v1.GASettings
+	(*FitnessScaling)(nil),        // 15: noytech.v1.FitnessScaling
+	(*DiversitySettings)(nil),     // 16: noytech.v1.DiversitySettings
+	(*StopConditions)(nil),        // 17: noytech.v1.StopConditions
+	(*PopulationInit)(nil),        // 18: noytech.v1.PopulationInit
+	(*TerminalSet)(nil),           // 19: noytech.v1.TerminalSet
+	(*LocalSearchSettings)(nil),   // 20: noytech.v1.LocalSearchSettings
+	(*IslandSettings)(nil),        // 21: noytech.v1.IslandSettings
+	(*IslandOperators)(nil),       // 22: noytech.v1.IslandOperators
+	(*OptimizeResponse)(nil),      // 23: noytech.v1.OptimizeResponse
+	(*OptimizationResult)(nil),    // 24: noytech.v1.OptimizationResult
+	(*GenerationStats)(nil),       // 25: noytech.v1.GenerationStats
+	(*BaselineComparison)(nil),    // 26: noytech.v1.BaselineComparison
+	(*ObjectiveValue)(nil),        // 27: noytech.v1.ObjectiveValue
+	(*RunStats)(nil),              // 28: noytech.v1.RunStats
+	(*FleetUsage)(nil),            // 29: noytech.v1.FleetUsage
+	(*Route)(nil),                 // 30: noytech.v1.Route
+	(*CostBreakdown)(nil),         // 31: noytech.v1.CostBreakdown
+	(*OptimizeEvent)(nil),         // 32: noytech.v1.OptimizeEvent
+	(*GenerationProgress)(nil),    // 33: noytech.v1.GenerationProgress
+	(*timestamppb.Timestamp)(nil), // 34: google.protobuf.Timestamp
 }
 var file_api_proto_optimizer_proto_depIdxs = []int32{
-	5,  // 0: noytech.v1.OptimizeRequest.ga_settings_level_1:type_name -> noytech.v1.GASettings
-	0,  // 1: noytech.v1.GASettings.selection_type:type_name -> noytech.v1.SelectionType
-	1,  // 2: noytech.v1.GASettings.crossover_type:type_name -> noytech.v1.CrossoverType
-	2,  // 3: noytech.v1.GASettings.mutation_type:type_name -> noytech.v1.MutationType
-	7,  // 4: noytech.v1.OptimizeResponse.results:type_name -> noytech.v1.OptimizationResult
-	10, // 5: noytech.v1.OptimizeResponse.created_at:type_name -> google.protobuf.Timestamp
-	8,  // 6: noytech.v1.OptimizationResult.routes:type_name -> noytech.v1.Route
-	9,  // 7: noytech.v1.OptimizationResult.cost:type_name -> noytech.v1.CostBreakdown
-	3,  // 8: noytech.v1.Route.transport_used:type_name -> noytech.v1.TransportType
-	4,  // 9: noytech.v1.OptimizerService.Optimize:input_type -> noytech.v1.OptimizeRequest
-	6,  // 10: noytech.v1.OptimizerService.Optimize:output_type -> noytech.v1.OptimizeResponse
-	10, // [10:11] is the sub-list for method output_type
-	9,  // [9:10] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	14, // 0: noytech.v1.OptimizeRequest.ga_settings_level_1:type_name -> noytech.v1.GASettings
+	14, // 1: noytech.v1.OptimizeRequest.ga_settings_level_2:type_name -> noytech.v1.GASettings
+	0,  // 2: noytech.v1.OptimizeRequest.algorithm:type_name -> noytech.v1.Algorithm
+	9,  // 3: noytech.v1.GASettings.selection_type:type_name -> noytech.v1.SelectionType
+	10, // 4: noytech.v1.GASettings.crossover_type:type_name -> noytech.v1.CrossoverType
+	11, // 5: noytech.v1.GASettings.mutation_type:type_name -> noytech.v1.MutationType
+	21, // 6: noytech.v1.GASettings.islands:type_name -> noytech.v1.IslandSettings
+	7,  // 7: noytech.v1.GASettings.objectives:type_name -> noytech.v1.Objective
+	20, // 8: noytech.v1.GASettings.local_search:type_name -> noytech.v1.LocalSearchSettings
+	18, // 9: noytech.v1.GASettings.init:type_name -> noytech.v1.PopulationInit
+	17, // 10: noytech.v1.GASettings.stop_conditions:type_name -> noytech.v1.StopConditions
+	16, // 11: noytech.v1.GASettings.diversity:type_name -> noytech.v1.DiversitySettings
+	15, // 12: noytech.v1.GASettings.scaling:type_name -> noytech.v1.FitnessScaling
+	1,  // 13: noytech.v1.FitnessScaling.method:type_name -> noytech.v1.ScalingMethod
+	2,  // 14: noytech.v1.DiversitySettings.niching:type_name -> noytech.v1.NichingMethod
+	4,  // 15: noytech.v1.PopulationInit.strategy:type_name -> noytech.v1.InitStrategy
+	19, // 16: noytech.v1.PopulationInit.terminal_sets:type_name -> noytech.v1.TerminalSet
+	5,  // 17: noytech.v1.LocalSearchSettings.strategy:type_name -> noytech.v1.LocalSearchStrategy
+	6,  // 18: noytech.v1.LocalSearchSettings.scope:type_name -> noytech.v1.LocalSearchScope
+	8,  // 19: noytech.v1.IslandSettings.topology:type_name -> noytech.v1.MigrationTopology
+	22, // 20: noytech.v1.IslandSettings.operators:type_name -> noytech.v1.IslandOperators
+	9,  // 21: noytech.v1.IslandOperators.selection_type:type_name -> noytech.v1.SelectionType
+	10, // 22: noytech.v1.IslandOperators.crossover_type:type_name -> noytech.v1.CrossoverType
+	11, // 23: noytech.v1.IslandOperators.mutation_type:type_name -> noytech.v1.MutationType
+	24, // 24: noytech.v1.OptimizeResponse.results:type_name -> noytech.v1.OptimizationResult
+	34, // 25: noytech.v1.OptimizeResponse.created_at:type_name -> google.protobuf.Timestamp
+	31, // 26: noytech.v1.OptimizeResponse.weekly_cost:type_name -> noytech.v1.CostBreakdown
+	30, // 27: noytech.v1.OptimizationResult.routes:type_name -> noytech.v1.Route
+	31, // 28: noytech.v1.OptimizationResult.cost:type_name -> noytech.v1.CostBreakdown
+	29, // 29: noytech.v1.OptimizationResult.fleet_usage:type_name -> noytech.v1.FleetUsage
+	28, // 30: noytech.v1.OptimizationResult.stats:type_name -> noytech.v1.RunStats
+	27, // 31: noytech.v1.OptimizationResult.objectives:type_name -> noytech.v1.ObjectiveValue
+	26, // 32: noytech.v1.OptimizationResult.baseline:type_name -> noytech.v1.BaselineComparison
+	3,  // 33: noytech.v1.OptimizationResult.stop_reason:type_name -> noytech.v1.StopReason
+	25, // 34: noytech.v1.OptimizationResult.history:type_name -> noytech.v1.GenerationStats
+	0,  // 35: noytech.v1.BaselineComparison.algorithm:type_name -> noytech.v1.Algorithm
+	7,  // 36: noytech.v1.ObjectiveValue.objective:type_name -> noytech.v1.Objective
+	33, // 37: noytech.v1.OptimizeEvent.progress:type_name -> noytech.v1.GenerationProgress
+	23, // 38: noytech.v1.OptimizeEvent.result:type_name -> noytech.v1.OptimizeResponse
+	13, // 39: noytech.v1.OptimizerService.Optimize:input_type -> noytech.v1.OptimizeRequest
+	13, // 40: noytech.v1.OptimizerService.OptimizeStream:input_type -> noytech.v1.OptimizeRequest
+	23, // 41: noytech.v1.OptimizerService.Optimize:output_type -> noytech.v1.OptimizeResponse
+	32, // 42: noytech.v1.OptimizerService.OptimizeStream:output_type -> noytech.v1.OptimizeEvent
+	41, // [41:43] is the sub-list for method output_type
+	39, // [39:41] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_optimizer_proto_init() }
@@ -797,13 +2950,14 @@ func file_api_proto_optimizer_proto_init() {
 	if File_api_proto_optimizer_proto != nil {
 		return
 	}
+	file_api_proto_optimizer_proto_msgTypes[1].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_optimizer_proto_rawDesc), len(file_api_proto_optimizer_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   6,
+			NumEnums:      13,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
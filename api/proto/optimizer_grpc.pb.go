@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OptimizerService_Optimize_FullMethodName = "/noytech.v1.OptimizerService/Optimize"
+	OptimizerService_Optimize_FullMethodName       = "/noytech.v1.OptimizerService/Optimize"
+	OptimizerService_OptimizeStream_FullMethodName = "/noytech.v1.OptimizerService/OptimizeStream"
 )
 
 // OptimizerServiceClient is the client API for OptimizerService service.
@@ -27,6 +28,9 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OptimizerServiceClient interface {
 	Optimize(ctx context.Context, in *OptimizeRequest, opts ...grpc.CallOption) (*OptimizeResponse, error)
+	// Оптимизация с потоком прогресса по поколениям ГА.
+	// Последнее сообщение потока содержит итоговый OptimizeResponse.
+	OptimizeStream(ctx context.Context, in *OptimizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OptimizeEvent], error)
 }
 
 type optimizerServiceClient struct {
@@ -47,11 +51,33 @@ func (c *optimizerServiceClient) Optimize(ctx context.Context, in *OptimizeReque
 	return out, nil
 }
 
+func (c *optimizerServiceClient) OptimizeStream(ctx context.Context, in *OptimizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OptimizeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OptimizerService_ServiceDesc.Streams[0], OptimizerService_OptimizeStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[OptimizeRequest, OptimizeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OptimizerService_OptimizeStreamClient = grpc.ServerStreamingClient[OptimizeEvent]
+
 // OptimizerServiceServer is the server API for OptimizerService service.
 // All implementations must embed UnimplementedOptimizerServiceServer
 // for forward compatibility.
 type OptimizerServiceServer interface {
 	Optimize(context.Context, *OptimizeRequest) (*OptimizeResponse, error)
+	// Оптимизация с потоком прогресса по поколениям ГА.
+	// Последнее сообщение потока содержит итоговый OptimizeResponse.
+	OptimizeStream(*OptimizeRequest, grpc.ServerStreamingServer[OptimizeEvent]) error
 	mustEmbedUnimplementedOptimizerServiceServer()
 }
 
@@ -65,6 +91,9 @@ type UnimplementedOptimizerServiceServer struct{}
 func (UnimplementedOptimizerServiceServer) Optimize(context.Context, *OptimizeRequest) (*OptimizeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Optimize not implemented")
 }
+func (UnimplementedOptimizerServiceServer) OptimizeStream(*OptimizeRequest, grpc.ServerStreamingServer[OptimizeEvent]) error {
+	return status.Error(codes.Unimplemented, "method OptimizeStream not implemented")
+}
 func (UnimplementedOptimizerServiceServer) mustEmbedUnimplementedOptimizerServiceServer() {}
 func (UnimplementedOptimizerServiceServer) testEmbeddedByValue()                          {}
 
@@ -104,6 +133,17 @@ func _OptimizerService_Optimize_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OptimizerService_OptimizeStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OptimizeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OptimizerServiceServer).OptimizeStream(m, &grpc.GenericServerStream[OptimizeRequest, OptimizeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OptimizerService_OptimizeStreamServer = grpc.ServerStreamingServer[OptimizeEvent]
+
 // OptimizerService_ServiceDesc is the grpc.ServiceDesc for OptimizerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -116,6 +156,12 @@ var OptimizerService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _OptimizerService_Optimize_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "OptimizeStream",
+			Handler:       _OptimizerService_OptimizeStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api/proto/optimizer.proto",
 }
@@ -3,17 +3,25 @@ package main
 import (
 	"context"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"runtime"
+	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 
+	"noytech-ga-optimizer/api/proto"
 	"noytech-ga-optimizer/internal/handler"
+	"noytech-ga-optimizer/internal/services/fleet"
 	"noytech-ga-optimizer/internal/services/importer"
+	"noytech-ga-optimizer/internal/services/jobs"
 	"noytech-ga-optimizer/internal/services/optimizer"
 	storages "noytech-ga-optimizer/internal/storages"
 )
@@ -34,6 +42,11 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap нужен http.ResponseController, чтобы добраться до Flush (SSE).
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 func loggingMiddleware(next http.Handler, logger *slog.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -61,6 +74,53 @@ func loggingMiddleware(next http.Handler, logger *slog.Logger) http.Handler {
 	})
 }
 
+func loggingUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		log := logger.With(slog.String("grpc_method", info.FullMethod))
+		log.Info("Request started")
+
+		resp, err := next(ctx, req)
+
+		log.Info("Request finished",
+			slog.String("code", status.Code(err).String()),
+			slog.Duration("duration", time.Since(start)),
+		)
+		return resp, err
+	}
+}
+
+func envInt(logger *slog.Logger, name string, def int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		logger.Warn("Invalid integer environment variable, using default", "name", name, "value", raw, "default", def)
+		return def
+	}
+	return v
+}
+
+func loggingStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, next grpc.StreamHandler) error {
+		start := time.Now()
+
+		log := logger.With(slog.String("grpc_method", info.FullMethod))
+		log.Info("Stream started")
+
+		err := next(srv, ss)
+
+		log.Info("Stream finished",
+			slog.String("code", status.Code(err).String()),
+			slog.Duration("duration", time.Since(start)),
+		)
+		return err
+	}
+}
+
 func main() {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
@@ -91,14 +151,38 @@ func main() {
 
 	store := storages.NewPostgresStorage(pool)
 	importerSvc := importer.New(store, logger)
-	optimizerSvc := optimizer.New(store, logger)
+	optimizerSvc := optimizer.New(store, optimizer.Config{
+		Workers:          envInt(logger, "GA_WORKERS", runtime.NumCPU()),
+		FitnessCacheSize: envInt(logger, "GA_FITNESS_CACHE_SIZE", 10000),
+	}, logger)
+	fleetSvc := fleet.New(store, logger)
+	jobsSvc := jobs.New(optimizerSvc, jobs.Config{
+		Workers:   envInt(logger, "JOB_WORKERS", runtime.NumCPU()),
+		QueueSize: envInt(logger, "JOB_QUEUE_SIZE", 100),
+	}, logger)
 
 	uploadHandler := handler.NewUploadHandler(importerSvc, logger)
 	optimizeHandler := handler.NewOptimizeHandler(optimizerSvc, logger)
+	jobsHandler := handler.NewJobsHandler(jobsSvc, logger)
+	solutionsHandler := handler.NewSolutionsHandler(optimizerSvc, logger)
+	fleetHandler := handler.NewFleetHandler(fleetSvc, logger)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("POST /upload", uploadHandler.HandleUpload)
+	// /optimize выполняет ГА синхронно в обработчике, в обход очереди задач; ограниченный пул воркеров — только у /jobs
 	mux.HandleFunc("POST /optimize", optimizeHandler.HandleOptimize)
+	mux.HandleFunc("POST /jobs", jobsHandler.HandleSubmit)
+	mux.HandleFunc("GET /jobs/{id}", jobsHandler.HandleGet)
+	mux.HandleFunc("DELETE /jobs/{id}", jobsHandler.HandleCancel)
+	mux.HandleFunc("GET /jobs/{id}/events", jobsHandler.HandleEvents)
+	mux.HandleFunc("GET /solutions", solutionsHandler.HandleList)
+	mux.HandleFunc("GET /solutions/{id}", solutionsHandler.HandleGet)
+	mux.HandleFunc("GET /fleet", fleetHandler.HandleList)
+	mux.HandleFunc("POST /fleet", fleetHandler.HandleCreate)
+	mux.HandleFunc("PUT /fleet/{id}", fleetHandler.HandleUpdate)
+	mux.HandleFunc("DELETE /fleet/{id}", fleetHandler.HandleDelete)
+	mux.HandleFunc("GET /fleet/availability", fleetHandler.HandleGetAvailability)
+	mux.HandleFunc("PUT /fleet/availability", fleetHandler.HandleSetAvailability)
 
 	finalHandler := loggingMiddleware(mux, logger)
 
@@ -107,6 +191,23 @@ func main() {
 		Handler: finalHandler,
 	}
 
+	grpcAddr := os.Getenv("GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":9090"
+	}
+
+	grpcListener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		logger.Error("Failed to listen for gRPC", "addr", grpcAddr, "error", err)
+		os.Exit(1)
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(loggingUnaryInterceptor(logger)),
+		grpc.ChainStreamInterceptor(loggingStreamInterceptor(logger)),
+	)
+	proto.RegisterOptimizerServiceServer(grpcServer, handler.NewOptimizerGRPCServer(optimizerSvc, logger))
+
 	logger.Info("Starting HTTP server", "addr", server.Addr)
 
 	go func() {
@@ -115,6 +216,14 @@ func main() {
 		}
 	}()
 
+	logger.Info("Starting gRPC server", "addr", grpcAddr)
+
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logger.Error("gRPC server failed", "error", err)
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -123,10 +232,28 @@ func main() {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		logger.Error("Server forced to shutdown", "error", err)
+		grpcServer.Stop()
 		os.Exit(1)
 	}
 
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		logger.Error("gRPC server forced to shutdown")
+		grpcServer.Stop()
+	}
+
+	if err := jobsSvc.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Job workers did not stop in time", "error", err)
+	}
+
 	logger.Info("Server stopped gracefully")
 }
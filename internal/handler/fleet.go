@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/fleet"
+	"noytech-ga-optimizer/internal/validation"
+	"noytech-ga-optimizer/pkg/errors"
+)
+
+type FleetHandler struct {
+	fleet  *fleet.Service
+	logger *slog.Logger
+}
+
+func NewFleetHandler(f *fleet.Service, l *slog.Logger) *FleetHandler {
+	return &FleetHandler{
+		fleet:  f,
+		logger: l,
+	}
+}
+
+func (h *FleetHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleListFleet"))
+
+	vehicles, err := h.fleet.List(r.Context())
+	if err != nil {
+		logger.Error("Failed to list fleet", "error", err)
+		h.sendError(w, errors.NewInternalServerError("failed to list fleet"), logger, r)
+		return
+	}
+
+	h.sendJSON(w, vehicles, http.StatusOK)
+}
+
+func (h *FleetHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleCreateVehicle"))
+
+	var v models.Vehicle
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		logger.Error("Failed to decode request body", "error", err)
+		h.sendError(w, errors.NewErrInvalidArgument(err, "invalid JSON in request body"), logger, r)
+		return
+	}
+	v.ID = strings.TrimSpace(v.ID)
+
+	if !h.validate(w, r, &v, logger) {
+		return
+	}
+
+	created, err := h.fleet.Create(r.Context(), v)
+	if err != nil {
+		h.handleServiceError(w, r, err, "failed to create vehicle", logger)
+		return
+	}
+
+	w.Header().Set("Location", "/fleet/"+created.ID)
+	h.sendJSON(w, created, http.StatusCreated)
+}
+
+// HandleUpdate заменяет класс ТС целиком; id берётся из пути.
+func (h *FleetHandler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleUpdateVehicle"))
+
+	var v models.Vehicle
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		logger.Error("Failed to decode request body", "error", err)
+		h.sendError(w, errors.NewErrInvalidArgument(err, "invalid JSON in request body"), logger, r)
+		return
+	}
+
+	id := r.PathValue("id")
+	if v.ID != "" && strings.TrimSpace(v.ID) != id {
+		h.sendError(w, errors.NewErrInvalidArgumentWithDetails([]errors.ErrorDetail{{
+			Field:   "id",
+			Message: "must match the id in the path",
+		}}), logger, r)
+		return
+	}
+	v.ID = id
+
+	if !h.validate(w, r, &v, logger) {
+		return
+	}
+
+	updated, err := h.fleet.Update(r.Context(), v)
+	if err != nil {
+		h.handleServiceError(w, r, err, "failed to update vehicle", logger)
+		return
+	}
+
+	h.sendJSON(w, updated, http.StatusOK)
+}
+
+func (h *FleetHandler) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleDeleteVehicle"))
+
+	if err := h.fleet.Delete(r.Context(), r.PathValue("id")); err != nil {
+		h.handleServiceError(w, r, err, "failed to delete vehicle", logger)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *FleetHandler) HandleGetAvailability(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleGetFleetAvailability"))
+
+	availability, err := h.fleet.GetAvailability(r.Context())
+	if err != nil {
+		logger.Error("Failed to get fleet availability", "error", err)
+		h.sendError(w, errors.NewInternalServerError("failed to get fleet availability"), logger, r)
+		return
+	}
+
+	h.sendJSON(w, availability, http.StatusOK)
+}
+
+// HandleSetAvailability заменяет все лимиты автопарка списком из тела запроса.
+func (h *FleetHandler) HandleSetAvailability(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleSetFleetAvailability"))
+
+	var availability []models.FleetAvailability
+	if err := json.NewDecoder(r.Body).Decode(&availability); err != nil {
+		logger.Error("Failed to decode request body", "error", err)
+		h.sendError(w, errors.NewErrInvalidArgument(err, "invalid JSON in request body"), logger, r)
+		return
+	}
+	for i := range availability {
+		availability[i].VehicleID = strings.TrimSpace(availability[i].VehicleID)
+		availability[i].DeliveryDay = strings.ToLower(strings.TrimSpace(availability[i].DeliveryDay))
+	}
+
+	if err := validation.ValidateFleetAvailability(availability); err != nil {
+		logger.Error("Validation failed", "error", err)
+		if customErr, ok := err.(*errors.ErrorResponse); ok {
+			h.sendError(w, customErr, logger, r)
+			return
+		}
+		h.sendError(w, errors.NewInternalServerError("validation error"), logger, r)
+		return
+	}
+
+	saved, err := h.fleet.SetAvailability(r.Context(), availability)
+	if err != nil {
+		h.handleServiceError(w, r, err, "failed to save fleet availability", logger)
+		return
+	}
+
+	h.sendJSON(w, saved, http.StatusOK)
+}
+
+func (h *FleetHandler) validate(w http.ResponseWriter, r *http.Request, v *models.Vehicle, logger *slog.Logger) bool {
+	if err := validation.ValidateVehicle(v); err != nil {
+		logger.Error("Validation failed", "error", err)
+		if customErr, ok := err.(*errors.ErrorResponse); ok {
+			h.sendError(w, customErr, logger, r)
+			return false
+		}
+		h.sendError(w, errors.NewInternalServerError("validation error"), logger, r)
+		return false
+	}
+	return true
+}
+
+func (h *FleetHandler) handleServiceError(w http.ResponseWriter, r *http.Request, err error, fallback string, logger *slog.Logger) {
+	if customErr, ok := err.(*errors.ErrorResponse); ok {
+		h.sendError(w, customErr, logger, r)
+		return
+	}
+	logger.Error("Fleet operation failed", "error", err)
+	h.sendError(w, errors.NewInternalServerError(fallback), logger, r)
+}
+
+func (h *FleetHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func (h *FleetHandler) sendError(w http.ResponseWriter, appErr *errors.ErrorResponse, logger *slog.Logger, r *http.Request) {
+	requestID := ""
+	if reqID := r.Context().Value("requestID"); reqID != nil {
+		if id, ok := reqID.(string); ok {
+			requestID = id
+		}
+	}
+
+	if requestID != "" && appErr.RequestID == "" {
+		appErr = errors.NewErrorResponseWithRequestID(appErr.Status, appErr.Message, appErr.Details, requestID)
+	}
+
+	if appErr.Status >= 500 {
+		logger.Error("Internal error", "status", appErr.Status, "error", appErr.Error(), "request_id", appErr.RequestID)
+	} else {
+		logger.Warn("Client error", "status", appErr.Status, "error", appErr.Error(), "request_id", appErr.RequestID)
+	}
+
+	h.sendJSON(w, appErr, appErr.Status)
+}
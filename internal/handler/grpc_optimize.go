@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/services/optimizer"
+	"noytech-ga-optimizer/internal/validation"
+)
+
+type OptimizerGRPCServer struct {
+	proto.UnimplementedOptimizerServiceServer
+	optimizer *optimizer.Service
+	logger    *slog.Logger
+}
+
+func NewOptimizerGRPCServer(opt *optimizer.Service, l *slog.Logger) *OptimizerGRPCServer {
+	return &OptimizerGRPCServer{
+		optimizer: opt,
+		logger:    l,
+	}
+}
+
+func (s *OptimizerGRPCServer) Optimize(ctx context.Context, req *proto.OptimizeRequest) (*proto.OptimizeResponse, error) {
+	logger := s.logger.With(slog.String("method", "GRPCOptimize"))
+
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+
+	normalizeOptimizeRequest(req)
+
+	if err := validation.ValidateOptimizeRequest(req); err != nil {
+		logger.Warn("Validation failed", "error", err)
+		return nil, toGRPCError(err)
+	}
+
+	resp, err := s.optimizer.Optimize(ctx, req)
+	if err != nil {
+		logger.Error("Optimizer service failed", "error", err)
+		return nil, toGRPCError(err)
+	}
+
+	logger.Info("Optimization completed successfully", "solution_id", resp.SolutionId)
+	return resp, nil
+}
+
+func (s *OptimizerGRPCServer) OptimizeStream(req *proto.OptimizeRequest, stream proto.OptimizerService_OptimizeStreamServer) error {
+	logger := s.logger.With(slog.String("method", "GRPCOptimizeStream"))
+
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "request is required")
+	}
+
+	normalizeOptimizeRequest(req)
+
+	if err := validation.ValidateOptimizeRequest(req); err != nil {
+		logger.Warn("Validation failed", "error", err)
+		return toGRPCError(err)
+	}
+
+	// Если клиент перестал читать поток, прерываем ГА
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var sendErr error
+	resp, err := s.optimizer.OptimizeWithProgress(ctx, req, func(p optimizer.Progress) {
+		if sendErr != nil {
+			return
+		}
+		if sendErr = stream.Send(&proto.OptimizeEvent{Progress: p.ToProto()}); sendErr != nil {
+			cancel()
+		}
+	})
+	if sendErr != nil {
+		logger.Warn("Failed to send progress", "error", sendErr)
+		return sendErr
+	}
+	if err != nil {
+		logger.Error("Optimizer service failed", "error", err)
+		return toGRPCError(err)
+	}
+
+	if err := stream.Send(&proto.OptimizeEvent{Result: resp}); err != nil {
+		logger.Warn("Failed to send result", "error", err)
+		return err
+	}
+
+	logger.Info("Optimization completed successfully", "solution_id", resp.SolutionId)
+	return nil
+}
+
+// toGRPCError переводит ошибку сервиса в gRPC-статус через GRPCStatus() из pkg/errors.
+// Ошибки без собственного статуса считаются внутренними.
+func toGRPCError(err error) error {
+	if stderrors.Is(err, context.Canceled) || stderrors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	var withStatus interface{ GRPCStatus() *status.Status }
+	if stderrors.As(err, &withStatus) {
+		return withStatus.GRPCStatus().Err()
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
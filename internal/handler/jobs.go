@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/services/jobs"
+	"noytech-ga-optimizer/internal/validation"
+	"noytech-ga-optimizer/pkg/errors"
+)
+
+type JobsHandler struct {
+	jobs   *jobs.Service
+	logger *slog.Logger
+}
+
+func NewJobsHandler(j *jobs.Service, l *slog.Logger) *JobsHandler {
+	return &JobsHandler{
+		jobs:   j,
+		logger: l,
+	}
+}
+
+func (h *JobsHandler) HandleSubmit(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleSubmitJob"))
+
+	var req proto.OptimizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("Failed to decode request body", "error", err)
+		appErr := errors.NewErrInvalidArgument(err, "invalid JSON in request body")
+		h.sendError(w, appErr, logger, r)
+		return
+	}
+
+	normalizeOptimizeRequest(&req)
+
+	if err := validation.ValidateOptimizeRequest(&req); err != nil {
+		logger.Error("Validation failed", "error", err)
+		if customErr, ok := err.(*errors.ErrorResponse); ok {
+			h.sendError(w, customErr, logger, r)
+			return
+		}
+		h.sendError(w, errors.NewInternalServerError("validation error"), logger, r)
+		return
+	}
+
+	job, err := h.jobs.Submit(&req)
+	if err != nil {
+		if customErr, ok := err.(*errors.ErrorResponse); ok {
+			h.sendError(w, customErr, logger, r)
+			return
+		}
+		h.sendError(w, errors.NewInternalServerError("failed to submit job"), logger, r)
+		return
+	}
+
+	logger.Info("Job submitted", "job_id", job.ID)
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	h.sendJSON(w, job, http.StatusAccepted)
+}
+
+func (h *JobsHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleGetJob"))
+
+	job, err := h.jobs.Get(r.PathValue("id"))
+	if err != nil {
+		if customErr, ok := err.(*errors.ErrorResponse); ok {
+			h.sendError(w, customErr, logger, r)
+			return
+		}
+		h.sendError(w, errors.NewInternalServerError("failed to get job"), logger, r)
+		return
+	}
+
+	h.sendJSON(w, job, http.StatusOK)
+}
+
+func (h *JobsHandler) HandleCancel(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleCancelJob"))
+
+	job, err := h.jobs.Cancel(r.PathValue("id"))
+	if err != nil {
+		if customErr, ok := err.(*errors.ErrorResponse); ok {
+			h.sendError(w, customErr, logger, r)
+			return
+		}
+		h.sendError(w, errors.NewInternalServerError("failed to cancel job"), logger, r)
+		return
+	}
+
+	logger.Info("Job cancellation requested", "job_id", job.ID)
+	h.sendJSON(w, job, http.StatusAccepted)
+}
+
+// HandleEvents отдаёт ход задачи как Server-Sent Events:
+// событие "progress" на каждое поколение ГА и финальное событие со статусом задачи ("done", "failed", "cancelled").
+func (h *JobsHandler) HandleEvents(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleJobEvents"))
+
+	job, events, unsubscribe, err := h.jobs.Subscribe(r.PathValue("id"))
+	if err != nil {
+		if customErr, ok := err.(*errors.ErrorResponse); ok {
+			h.sendError(w, customErr, logger, r)
+			return
+		}
+		h.sendError(w, errors.NewInternalServerError("failed to subscribe to job"), logger, r)
+		return
+	}
+	defer unsubscribe()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if job.Status.Finished() {
+		h.writeEvent(w, rc, string(job.Status), job, logger)
+		return
+	}
+	h.writeEvent(w, rc, "progress", job.Progress, logger)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.Job != nil {
+				h.writeEvent(w, rc, string(event.Job.Status), event.Job, logger)
+				return
+			}
+			if !h.writeEvent(w, rc, "progress", event.Progress, logger) {
+				return
+			}
+		}
+	}
+}
+
+func (h *JobsHandler) writeEvent(w http.ResponseWriter, rc *http.ResponseController, name string, data interface{}, logger *slog.Logger) bool {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		logger.Error("Failed to encode event", "event", name, "error", err)
+		return false
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload); err != nil {
+		return false
+	}
+	if err := rc.Flush(); err != nil {
+		logger.Warn("Failed to flush event", "event", name, "error", err)
+		return false
+	}
+	return true
+}
+
+func (h *JobsHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func (h *JobsHandler) sendError(w http.ResponseWriter, appErr *errors.ErrorResponse, logger *slog.Logger, r *http.Request) {
+	requestID := ""
+	if reqID := r.Context().Value("requestID"); reqID != nil {
+		if id, ok := reqID.(string); ok {
+			requestID = id
+		}
+	}
+
+	if requestID != "" && appErr.RequestID == "" {
+		appErr = errors.NewErrorResponseWithRequestID(appErr.Status, appErr.Message, appErr.Details, requestID)
+	}
+
+	if appErr.Status >= 500 {
+		logger.Error("Internal error", "status", appErr.Status, "error", appErr.Error(), "request_id", appErr.RequestID)
+	} else {
+		logger.Warn("Client error", "status", appErr.Status, "error", appErr.Error(), "request_id", appErr.RequestID)
+	}
+
+	h.sendJSON(w, appErr, appErr.Status)
+}
@@ -6,9 +6,6 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/google/uuid"
-	"google.golang.org/protobuf/types/known/timestamppb"
-
 	"noytech-ga-optimizer/api/proto"
 	"noytech-ga-optimizer/internal/services/optimizer"
 	"noytech-ga-optimizer/internal/validation"
@@ -44,10 +41,7 @@ func (h *OptimizeHandler) HandleOptimize(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	req.Direction = strings.TrimSpace(req.Direction)
-	for i, day := range req.DeliveryDays {
-		req.DeliveryDays[i] = strings.TrimSpace(day)
-	}
+	normalizeOptimizeRequest(&req)
 
 	if err := validation.ValidateOptimizeRequest(&req); err != nil {
 		logger.Error("Validation failed", "error", err)
@@ -61,7 +55,7 @@ func (h *OptimizeHandler) HandleOptimize(w http.ResponseWriter, r *http.Request)
 	}
 
 	ctx := r.Context()
-	result, err := h.optimizer.Optimize(ctx, &req)
+	resp, err := h.optimizer.Optimize(ctx, &req)
 	if err != nil {
 		logger.Error("Optimizer service failed", "error", err)
 		if customErr, ok := err.(*errors.ErrorResponse); ok {
@@ -73,18 +67,31 @@ func (h *OptimizeHandler) HandleOptimize(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	resp := &proto.OptimizeResponse{
-		Success:    true,
-		Message:    "Optimization completed successfully",
-		Results:    []*proto.OptimizationResult{result},
-		SolutionId: uuid.NewString(),
-		CreatedAt:  timestamppb.Now(),
-	}
-
 	logger.Info("Optimization completed successfully")
 	h.sendJSON(w, resp, http.StatusOK)
 }
 
+func normalizeOptimizeRequest(req *proto.OptimizeRequest) {
+	req.Direction = strings.TrimSpace(req.Direction)
+	for i, day := range req.DeliveryDays {
+		req.DeliveryDays[i] = strings.TrimSpace(day)
+	}
+	for i, city := range req.MustOpen {
+		req.MustOpen[i] = strings.TrimSpace(city)
+	}
+	for i, city := range req.MustClose {
+		req.MustClose[i] = strings.TrimSpace(city)
+	}
+	if init := req.GaSettingsLevel_1.GetInit(); init != nil {
+		init.SolutionId = strings.TrimSpace(init.SolutionId)
+		for _, set := range init.TerminalSets {
+			for i, city := range set.ActiveTerminals {
+				set.ActiveTerminals[i] = strings.TrimSpace(city)
+			}
+		}
+	}
+}
+
 func (h *OptimizeHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer"
+	"noytech-ga-optimizer/internal/validation"
+	"noytech-ga-optimizer/pkg/errors"
+)
+
+const (
+	DefaultSolutionsLimit = 20
+	MaxSolutionsLimit     = 100
+)
+
+type SolutionsHandler struct {
+	optimizer *optimizer.Service
+	logger    *slog.Logger
+}
+
+func NewSolutionsHandler(opt *optimizer.Service, l *slog.Logger) *SolutionsHandler {
+	return &SolutionsHandler{
+		optimizer: opt,
+		logger:    l,
+	}
+}
+
+func (h *SolutionsHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleListSolutions"))
+
+	filter, appErr := parseSolutionFilter(r.URL.Query())
+	if appErr != nil {
+		h.sendError(w, appErr, logger, r)
+		return
+	}
+
+	list, err := h.optimizer.ListSolutions(r.Context(), filter)
+	if err != nil {
+		logger.Error("Failed to list solutions", "error", err)
+		h.sendError(w, errors.NewInternalServerError("failed to list solutions"), logger, r)
+		return
+	}
+
+	h.sendJSON(w, list, http.StatusOK)
+}
+
+func (h *SolutionsHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.With(slog.String("method", "HandleGetSolution"))
+
+	id := r.PathValue("id")
+	if _, err := uuid.Parse(id); err != nil {
+		h.sendError(w, errors.NewNotFoundError("solution not found"), logger, r)
+		return
+	}
+
+	solution, err := h.optimizer.GetSolution(r.Context(), id)
+	if err != nil {
+		if customErr, ok := err.(*errors.ErrorResponse); ok {
+			h.sendError(w, customErr, logger, r)
+			return
+		}
+		logger.Error("Failed to get solution", "solution_id", id, "error", err)
+		h.sendError(w, errors.NewInternalServerError("failed to get solution"), logger, r)
+		return
+	}
+
+	h.sendJSON(w, solution, http.StatusOK)
+}
+
+// parseSolutionFilter разбирает параметры limit, offset, direction, from и to (YYYY-MM-DD, включительно).
+func parseSolutionFilter(query url.Values) (models.SolutionFilter, *errors.ErrorResponse) {
+	filter := models.SolutionFilter{Limit: DefaultSolutionsLimit}
+	var details []errors.ErrorDetail
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 || limit > MaxSolutionsLimit {
+			details = append(details, errors.ErrorDetail{
+				Field:   "limit",
+				Message: fmt.Sprintf("must be an integer between 1 and %d", MaxSolutionsLimit),
+			})
+		} else {
+			filter.Limit = limit
+		}
+	}
+
+	if raw := query.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			details = append(details, errors.ErrorDetail{
+				Field:   "offset",
+				Message: "must be a non-negative integer",
+			})
+		} else {
+			filter.Offset = offset
+		}
+	}
+
+	if direction := strings.TrimSpace(query.Get("direction")); direction != "" {
+		if !validation.AllowedDirections[direction] {
+			details = append(details, errors.ErrorDetail{
+				Field:   "direction",
+				Message: fmt.Sprintf("value '%s' is not allowed", direction),
+			})
+		} else {
+			filter.Direction = direction
+		}
+	}
+
+	if raw := query.Get("from"); raw != "" {
+		from, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			details = append(details, errors.ErrorDetail{
+				Field:   "from",
+				Message: "must be a date in format YYYY-MM-DD",
+			})
+		} else {
+			filter.From = &from
+		}
+	}
+
+	if raw := query.Get("to"); raw != "" {
+		to, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			details = append(details, errors.ErrorDetail{
+				Field:   "to",
+				Message: "must be a date in format YYYY-MM-DD",
+			})
+		} else {
+			to = to.AddDate(0, 0, 1)
+			filter.To = &to
+		}
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		details = append(details, errors.ErrorDetail{
+			Field:   "from",
+			Message: "must not be after 'to'",
+		})
+	}
+
+	if len(details) > 0 {
+		return filter, errors.NewErrInvalidArgumentWithDetails(details)
+	}
+	return filter, nil
+}
+
+func (h *SolutionsHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func (h *SolutionsHandler) sendError(w http.ResponseWriter, appErr *errors.ErrorResponse, logger *slog.Logger, r *http.Request) {
+	requestID := ""
+	if reqID := r.Context().Value("requestID"); reqID != nil {
+		if id, ok := reqID.(string); ok {
+			requestID = id
+		}
+	}
+
+	if requestID != "" && appErr.RequestID == "" {
+		appErr = errors.NewErrorResponseWithRequestID(appErr.Status, appErr.Message, appErr.Details, requestID)
+	}
+
+	if appErr.Status >= 500 {
+		logger.Error("Internal error", "status", appErr.Status, "error", appErr.Error(), "request_id", appErr.RequestID)
+	} else {
+		logger.Warn("Client error", "status", appErr.Status, "error", appErr.Error(), "request_id", appErr.RequestID)
+	}
+
+	h.sendJSON(w, appErr, appErr.Status)
+}
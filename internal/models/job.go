@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"noytech-ga-optimizer/api/proto"
+)
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusDone      JobStatus = "done"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// Finished сообщает, что задача больше не будет выполняться.
+func (s JobStatus) Finished() bool {
+	return s == JobStatusDone || s == JobStatusFailed || s == JobStatusCancelled
+}
+
+type Job struct {
+	ID         string                  `json:"id"`
+	Status     JobStatus               `json:"status"`
+	Progress   JobProgress             `json:"progress"`
+	Result     *proto.OptimizeResponse `json:"result,omitempty"`
+	Error      string                  `json:"error,omitempty"`
+	CreatedAt  time.Time               `json:"created_at"`
+	StartedAt  *time.Time              `json:"started_at,omitempty"`
+	FinishedAt *time.Time              `json:"finished_at,omitempty"`
+}
+
+type JobProgress struct {
+	DeliveryDay     string   `json:"delivery_day,omitempty"`
+	DaysCompleted   int      `json:"days_completed"`
+	DaysTotal       int      `json:"days_total"`
+	Generation      int      `json:"generation"`
+	NumGenerations  int      `json:"num_generations"`
+	BestFitness     float64  `json:"best_fitness,omitempty"`
+	MeanFitness     float64  `json:"mean_fitness,omitempty"`
+	WorstFitness    float64  `json:"worst_fitness,omitempty"`
+	NoImprovement   int      `json:"no_improvement"`
+	ActiveTerminals []string `json:"active_terminals,omitempty"`
+	Percent         float64  `json:"percent"`
+}
@@ -8,4 +8,5 @@ type Shipment struct {
 	VolumeM3        float64   `json:"volume_m3"`
 	DestinationCity string    `json:"destination_city"`
 	Date            time.Time `json:"date"`
+	Pallets         int       `json:"pallets"` // Паллетомест; 0 — не указано
 }
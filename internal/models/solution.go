@@ -0,0 +1,43 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Solution — сохранённый результат оптимизации.
+type Solution struct {
+	ID                 string          `json:"id"`
+	Direction          string          `json:"direction"`
+	DeliveryDays       []string        `json:"delivery_days"`
+	Request            json.RawMessage `json:"request,omitempty"`
+	GASettings         json.RawMessage `json:"ga_settings,omitempty"`
+	DatasetFingerprint string          `json:"dataset_fingerprint"`
+	Results            json.RawMessage `json:"results,omitempty"`
+	Cost               SolutionCost    `json:"cost"`
+	StartedAt          time.Time       `json:"started_at"`
+	CreatedAt          time.Time       `json:"created_at"`
+}
+
+type SolutionCost struct {
+	LinehaulCost float64 `json:"linehaul_cost"`
+	LastMileCost float64 `json:"last_mile_cost"`
+	PenaltyCost  float64 `json:"penalty_cost"`
+	TotalCost    float64 `json:"total_cost"`
+}
+
+// SolutionFilter — параметры выборки списка решений.
+type SolutionFilter struct {
+	Direction string
+	From      *time.Time // Включительно
+	To        *time.Time // Не включительно
+	Limit     int
+	Offset    int
+}
+
+type SolutionList struct {
+	Items  []Solution `json:"items"`
+	Total  int        `json:"total"`
+	Limit  int        `json:"limit"`
+	Offset int        `json:"offset"`
+}
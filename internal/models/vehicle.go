@@ -0,0 +1,20 @@
+package models
+
+// Vehicle — класс ТС из справочника автопарка.
+type Vehicle struct {
+	ID              string  `json:"id"`               // Код класса в справочнике, например "20t_86m3"
+	Name            string  `json:"name"`             // Название для отчётов
+	CapacityTons    float64 `json:"capacity_tons"`    // Грузоподъёмность, тонны
+	CapacityM3      float64 `json:"capacity_m3"`      // Объём кузова, м³
+	CapacityPallets int     `json:"capacity_pallets"` // Вместимость, паллеты
+	CostPerKm       float64 `json:"cost_per_km"`      // Стоимость км рейса; 0 — по тарифу на межгород
+	CostPerTrip     float64 `json:"cost_per_trip"`    // Фиксированная стоимость рейса
+}
+
+// FleetAvailability — сколько ТС класса доступно в день отгрузки.
+// Класс без записи на день считается доступным без ограничений.
+type FleetAvailability struct {
+	VehicleID   string `json:"vehicle_id"`
+	DeliveryDay string `json:"delivery_day"` // mon, tue, ..., sun
+	Count       int    `json:"count"`
+}
@@ -0,0 +1,116 @@
+package fleet
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+
+	"noytech-ga-optimizer/internal/models"
+	storage "noytech-ga-optimizer/internal/storages"
+	"noytech-ga-optimizer/pkg/errors"
+)
+
+// Service — справочник автопарка: классы ТС, из которых ГА собирает рейсы.
+type Service struct {
+	storage storage.Storage
+	logger  *slog.Logger
+}
+
+func New(s storage.Storage, l *slog.Logger) *Service {
+	return &Service{
+		storage: s,
+		logger:  l,
+	}
+}
+
+// List возвращает классы ТС по возрастанию вместимости.
+func (svc *Service) List(ctx context.Context) ([]models.Vehicle, error) {
+	vehicles, err := svc.storage.GetAllVehicles(ctx)
+	if err != nil {
+		return nil, errors.NewErrInternal(err, "failed to load fleet")
+	}
+	if vehicles == nil {
+		vehicles = []models.Vehicle{}
+	}
+	return vehicles, nil
+}
+
+func (svc *Service) Create(ctx context.Context, v models.Vehicle) (models.Vehicle, error) {
+	err := svc.storage.InsertVehicle(ctx, v)
+	if stderrors.Is(err, errors.ErrAlreadyExists) {
+		return models.Vehicle{}, errors.NewConflictError("vehicle with this id already exists")
+	}
+	if err != nil {
+		return models.Vehicle{}, errors.NewErrInternal(err, "failed to create vehicle")
+	}
+	svc.logger.Info("Vehicle added to fleet", "vehicle_id", v.ID)
+	return v, nil
+}
+
+func (svc *Service) Update(ctx context.Context, v models.Vehicle) (models.Vehicle, error) {
+	err := svc.storage.UpdateVehicle(ctx, v)
+	if stderrors.Is(err, errors.ErrNotFound) {
+		return models.Vehicle{}, errors.NewNotFoundError("vehicle not found")
+	}
+	if err != nil {
+		return models.Vehicle{}, errors.NewErrInternal(err, "failed to update vehicle")
+	}
+	svc.logger.Info("Vehicle updated", "vehicle_id", v.ID)
+	return v, nil
+}
+
+func (svc *Service) Delete(ctx context.Context, id string) error {
+	err := svc.storage.DeleteVehicle(ctx, id)
+	if stderrors.Is(err, errors.ErrNotFound) {
+		return errors.NewNotFoundError("vehicle not found")
+	}
+	if err != nil {
+		return errors.NewErrInternal(err, "failed to delete vehicle")
+	}
+	svc.logger.Info("Vehicle removed from fleet", "vehicle_id", id)
+	return nil
+}
+
+// GetAvailability возвращает лимиты автопарка по классам и дням отгрузки.
+func (svc *Service) GetAvailability(ctx context.Context) ([]models.FleetAvailability, error) {
+	availability, err := svc.storage.GetFleetAvailability(ctx)
+	if err != nil {
+		return nil, errors.NewErrInternal(err, "failed to load fleet availability")
+	}
+	if availability == nil {
+		availability = []models.FleetAvailability{}
+	}
+	return availability, nil
+}
+
+// SetAvailability заменяет все лимиты автопарка. Классы без записи на день не ограничены.
+func (svc *Service) SetAvailability(ctx context.Context, availability []models.FleetAvailability) ([]models.FleetAvailability, error) {
+	vehicles, err := svc.storage.GetAllVehicles(ctx)
+	if err != nil {
+		return nil, errors.NewErrInternal(err, "failed to load fleet")
+	}
+	known := make(map[string]bool, len(vehicles))
+	for _, v := range vehicles {
+		known[v.ID] = true
+	}
+
+	var details []errors.ErrorDetail
+	for i, a := range availability {
+		if !known[a.VehicleID] {
+			details = append(details, errors.ErrorDetail{
+				Field:   fmt.Sprintf("[%d].vehicle_id", i),
+				Message: fmt.Sprintf("vehicle '%s' is not in the fleet catalog", a.VehicleID),
+			})
+		}
+	}
+	if len(details) > 0 {
+		return nil, errors.NewErrInvalidArgumentWithDetails(details)
+	}
+
+	if err := svc.storage.ReplaceFleetAvailability(ctx, availability); err != nil {
+		return nil, errors.NewErrInternal(err, "failed to save fleet availability")
+	}
+	svc.logger.Info("Fleet availability updated", "entries", len(availability))
+	return availability, nil
+}
@@ -72,12 +72,23 @@ func parseAndLoadShipmentsAndTerminals(ctx context.Context, storage storage.Stor
 			continue
 		}
 
+		// Необязательная колонка «Паллет»; пустая — не указано
+		pallets := 0
+		if len(row) > 8 && strings.TrimSpace(row[8]) != "" {
+			pallets, err = strconv.Atoi(strings.TrimSpace(row[8]))
+			if err != nil || pallets < 0 {
+				logger.Warn("Skipping shipment due to invalid pallets", "row_index", i, "pallets", row[8])
+				continue
+			}
+		}
+
 		shipment := models.Shipment{
 			ID:              strings.TrimSpace(row[0]),
 			WeightKg:        weight,
 			VolumeM3:        volume,
 			DestinationCity: strings.TrimSpace(row[3]),
 			Date:            date,
+			Pallets:         pallets,
 		}
 
 		shipments = append(shipments, shipment)
@@ -468,3 +479,92 @@ func getDirectionName(code string) string {
 	}
 	return ""
 }
+
+// parseAndLoadFleet загружает справочник автопарка из листа "Автопарк":
+// Код | Название | Грузоподъёмность, т | Объём, м3 | Паллет | Руб/км | Руб/рейс.
+// Справочник заменяется целиком, только если в листе есть хотя бы одна валидная строка,
+// иначе остаётся текущий (заведённый через API).
+func parseAndLoadFleet(ctx context.Context, storage storage.Storage, f *excelize.File, logger *slog.Logger) error {
+	logger = logger.With(slog.String("submethod", "parseAndLoadFleet"))
+
+	rows, err := f.GetRows("Автопарк")
+	if err != nil {
+		logger.Info("Sheet 'Автопарк' not found, keeping current fleet")
+		return nil
+	}
+
+	parseNumber := func(row []string, col int) (float64, error) {
+		if col >= len(row) || strings.TrimSpace(row[col]) == "" {
+			return 0, nil
+		}
+		return strconv.ParseFloat(strings.Replace(strings.TrimSpace(row[col]), ",", ".", -1), 64)
+	}
+
+	seen := make(map[string]bool)
+	var vehicles []models.Vehicle
+	for i, row := range rows {
+		if i == 0 {
+			continue
+		}
+
+		if len(row) < 4 {
+			continue
+		}
+
+		id := strings.TrimSpace(row[0])
+		if id == "" {
+			logger.Warn("Skipping vehicle due to empty code", "row_index", i, "row", row)
+			continue
+		}
+		if seen[id] {
+			return errors.NewUnprocessableEntityError(fmt.Sprintf("Ошибка при загрузке 'Автопарк': код ТС '%s' встречается несколько раз.", id))
+		}
+
+		tons, errT := parseNumber(row, 2)
+		m3, errM := parseNumber(row, 3)
+		pallets, errP := parseNumber(row, 4)
+		costPerKm, errK := parseNumber(row, 5)
+		costPerTrip, errR := parseNumber(row, 6)
+		if errT != nil || errM != nil || errP != nil || errK != nil || errR != nil {
+			logger.Warn("Skipping vehicle due to invalid number format", "row_index", i, "row", row)
+			continue
+		}
+		if tons <= 0 || m3 <= 0 {
+			logger.Warn("Skipping vehicle due to non-positive capacity", "row_index", i, "id", id, "capacity_tons", tons, "capacity_m3", m3)
+			continue
+		}
+		if pallets < 0 || costPerKm < 0 || costPerTrip < 0 {
+			logger.Warn("Skipping vehicle due to negative pallets or cost", "row_index", i, "id", id)
+			continue
+		}
+
+		name := ""
+		if len(row) > 1 {
+			name = strings.TrimSpace(row[1])
+		}
+
+		seen[id] = true
+		vehicles = append(vehicles, models.Vehicle{
+			ID:              id,
+			Name:            name,
+			CapacityTons:    tons,
+			CapacityM3:      m3,
+			CapacityPallets: int(pallets),
+			CostPerKm:       costPerKm,
+			CostPerTrip:     costPerTrip,
+		})
+	}
+
+	if len(vehicles) == 0 {
+		logger.Warn("No valid vehicles found in 'Автопарк' sheet, keeping current fleet")
+		return nil
+	}
+
+	// Классы обновляются на месте, а не через TRUNCATE: иначе каскадно удалились бы лимиты fleet_availability
+	if err := storage.ReplaceVehicles(ctx, vehicles); err != nil {
+		return errors.NewErrInternal(err, "failed to import fleet")
+	}
+	logger.Info("Imported vehicles", "count", len(vehicles))
+
+	return nil
+}
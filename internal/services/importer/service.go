@@ -122,6 +122,10 @@ func (svc *Service) processStatFile(ctx context.Context, file *FileData) error {
 		return fmt.Errorf("load intra-city rates: %w", err)
 	}
 
+	if err := svc.loadFleet(ctx, f, logger); err != nil {
+		return fmt.Errorf("load fleet: %w", err)
+	}
+
 	return nil
 }
 
@@ -153,6 +157,10 @@ func (svc *Service) loadIntraCityRates(ctx context.Context, f *excelize.File, lo
 	return parseAndLoadIntraCityRates(ctx, svc.storage, f, logger)
 }
 
+func (svc *Service) loadFleet(ctx context.Context, f *excelize.File, logger *slog.Logger) error {
+	return parseAndLoadFleet(ctx, svc.storage, f, logger)
+}
+
 func (svc *Service) loadDistances(ctx context.Context, f *excelize.File, logger *slog.Logger) error {
 	return parseAndLoadDistances(ctx, svc.storage, f, logger)
 }
@@ -0,0 +1,324 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer"
+	"noytech-ga-optimizer/pkg/errors"
+)
+
+// Config — параметры пула воркеров.
+type Config struct {
+	Workers   int           // Количество одновременно выполняемых оптимизаций
+	QueueSize int           // Максимальное число задач в очереди
+	TTL       time.Duration // Сколько хранить завершённые задачи
+}
+
+type job struct {
+	state       models.Job
+	req         *proto.OptimizeRequest
+	ctx         context.Context
+	cancel      context.CancelFunc
+	subscribers map[chan Event]struct{}
+}
+
+// Event — изменение состояния задачи для подписчиков.
+// Progress заполнен для очередного поколения, Job — когда задача завершилась.
+type Event struct {
+	Progress *models.JobProgress
+	Job      *models.Job
+}
+
+// subscriberBuffer — сколько событий подписчик может не вычитывать, прежде чем они начнут теряться.
+const subscriberBuffer = 256
+
+type Service struct {
+	optimizer *optimizer.Service
+	logger    *slog.Logger
+	cfg       Config
+
+	queue  chan *job
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu   sync.RWMutex
+	jobs map[string]*job
+}
+
+func New(opt *optimizer.Service, cfg Config, l *slog.Logger) *Service {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 100
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = time.Hour
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	svc := &Service{
+		optimizer: opt,
+		logger:    l,
+		cfg:       cfg,
+		queue:     make(chan *job, cfg.QueueSize),
+		ctx:       ctx,
+		cancel:    cancel,
+		jobs:      make(map[string]*job),
+	}
+
+	for i := 0; i < cfg.Workers; i++ {
+		svc.wg.Add(1)
+		go svc.worker()
+	}
+
+	return svc
+}
+
+// Submit ставит запрос на оптимизацию в очередь и сразу возвращает задачу.
+func (svc *Service) Submit(req *proto.OptimizeRequest) (models.Job, error) {
+	svc.pruneFinished()
+
+	ctx, cancel := context.WithCancel(svc.ctx)
+	j := &job{
+		state: models.Job{
+			ID:        uuid.NewString(),
+			Status:    models.JobStatusQueued,
+			Progress:  models.JobProgress{DaysTotal: len(req.DeliveryDays)},
+			CreatedAt: time.Now(),
+		},
+		req:         req,
+		ctx:         ctx,
+		cancel:      cancel,
+		subscribers: make(map[chan Event]struct{}),
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	select {
+	case svc.queue <- j:
+	default:
+		cancel()
+		return models.Job{}, errors.NewServiceUnavailableError("job queue is full, try again later")
+	}
+
+	svc.jobs[j.state.ID] = j
+	svc.logger.Info("Job queued", "job_id", j.state.ID)
+	return j.state, nil
+}
+
+func (svc *Service) Get(id string) (models.Job, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+
+	j, ok := svc.jobs[id]
+	if !ok {
+		return models.Job{}, errors.NewNotFoundError("job not found")
+	}
+	return j.state, nil
+}
+
+// Cancel отменяет задачу в очереди или прерывает выполняющийся ГА.
+func (svc *Service) Cancel(id string) (models.Job, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	j, ok := svc.jobs[id]
+	if !ok {
+		return models.Job{}, errors.NewNotFoundError("job not found")
+	}
+	if j.state.Status.Finished() {
+		return j.state, errors.NewConflictError("job is already finished")
+	}
+
+	j.cancel()
+	if j.state.Status == models.JobStatusQueued {
+		svc.finish(j, models.JobStatusCancelled, nil, "")
+	}
+
+	svc.logger.Info("Job cancellation requested", "job_id", id)
+	return j.state, nil
+}
+
+// Subscribe возвращает текущее состояние задачи и канал с последующими событиями.
+// Канал закрывается после события о завершении задачи или вызова unsubscribe.
+func (svc *Service) Subscribe(id string) (models.Job, <-chan Event, func(), error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	j, ok := svc.jobs[id]
+	if !ok {
+		return models.Job{}, nil, nil, errors.NewNotFoundError("job not found")
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	if j.state.Status.Finished() {
+		close(ch)
+		return j.state, ch, func() {}, nil
+	}
+
+	j.subscribers[ch] = struct{}{}
+	unsubscribe := func() {
+		svc.mu.Lock()
+		defer svc.mu.Unlock()
+		if _, ok := j.subscribers[ch]; ok {
+			delete(j.subscribers, ch)
+			close(ch)
+		}
+	}
+	return j.state, ch, unsubscribe, nil
+}
+
+// Shutdown отменяет все задачи и ждёт остановки воркеров.
+func (svc *Service) Shutdown(ctx context.Context) error {
+	svc.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		svc.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (svc *Service) worker() {
+	defer svc.wg.Done()
+
+	for {
+		select {
+		case <-svc.ctx.Done():
+			return
+		case j := <-svc.queue:
+			svc.run(j)
+		}
+	}
+}
+
+func (svc *Service) run(j *job) {
+	logger := svc.logger.With(slog.String("job_id", j.state.ID))
+
+	svc.mu.Lock()
+	if j.state.Status != models.JobStatusQueued {
+		svc.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	j.state.Status = models.JobStatusRunning
+	j.state.StartedAt = &now
+	svc.mu.Unlock()
+
+	logger.Info("Job started")
+
+	resp, err := svc.optimizer.OptimizeWithProgress(j.ctx, j.req, func(p optimizer.Progress) {
+		svc.updateProgress(j, p)
+	})
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	switch {
+	case j.ctx.Err() != nil:
+		svc.finish(j, models.JobStatusCancelled, nil, "job was cancelled")
+		logger.Info("Job cancelled")
+	case err != nil:
+		svc.finish(j, models.JobStatusFailed, nil, err.Error())
+		logger.Error("Job failed", "error", err)
+	default:
+		svc.finish(j, models.JobStatusDone, resp, "")
+		logger.Info("Job completed", "solution_id", resp.SolutionId)
+	}
+}
+
+func (svc *Service) updateProgress(j *job, p optimizer.Progress) {
+	percent := 0.0
+	if p.DaysTotal > 0 && p.NumGenerations > 0 {
+		dayFraction := float64(p.Generation) / float64(p.NumGenerations)
+		percent = (float64(p.DayIndex) + dayFraction) / float64(p.DaysTotal) * 100
+	}
+
+	progress := models.JobProgress{
+		DeliveryDay:     p.DeliveryDay,
+		DaysCompleted:   p.DayIndex,
+		DaysTotal:       p.DaysTotal,
+		Generation:      p.Generation,
+		NumGenerations:  p.NumGenerations,
+		BestFitness:     p.BestFitness,
+		MeanFitness:     p.MeanFitness,
+		WorstFitness:    p.WorstFitness,
+		NoImprovement:   p.NoImprovement,
+		ActiveTerminals: p.ActiveTerminals,
+		Percent:         percent,
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	j.state.Progress = progress
+	svc.publish(j, Event{Progress: &progress})
+}
+
+// publish рассылает событие подписчикам, не блокируя ГА. Вызывается под svc.mu.
+func (svc *Service) publish(j *job, event Event) {
+	for ch := range j.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// finish переводит задачу в конечный статус. Вызывается под svc.mu.
+func (svc *Service) finish(j *job, status models.JobStatus, resp *proto.OptimizeResponse, errMsg string) {
+	now := time.Now()
+	j.state.Status = status
+	j.state.Result = resp
+	j.state.Error = errMsg
+	j.state.FinishedAt = &now
+	if status == models.JobStatusDone {
+		j.state.Progress.DaysCompleted = j.state.Progress.DaysTotal
+		j.state.Progress.Percent = 100
+	}
+	j.cancel()
+
+	final := j.state
+	for ch := range j.subscribers {
+		// Финальное событие не должно потеряться: освобождаем место в буфере, если он полон
+		select {
+		case ch <- Event{Job: &final}:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- Event{Job: &final}
+		}
+		delete(j.subscribers, ch)
+		close(ch)
+	}
+}
+
+func (svc *Service) pruneFinished() {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	for id, j := range svc.jobs {
+		if j.state.FinishedAt != nil && time.Since(*j.state.FinishedAt) > svc.cfg.TTL {
+			delete(svc.jobs, id)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package optimizer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+
+	"noytech-ga-optimizer/internal/models"
+)
+
+// datasetFingerprint считает SHA-256 от входных данных оптимизации.
+// Записи сортируются, поэтому порядок выборки из БД на результат не влияет.
+func datasetFingerprint(
+	shipments []models.Shipment,
+	terminals []models.Terminal,
+	distances []models.Distance,
+	interCityRates []models.InterCityRate,
+	intraCityRates []models.IntraCityRate,
+	fleet []models.Vehicle,
+	availability []models.FleetAvailability,
+) string {
+	h := sha256.New()
+
+	lines := make([]string, 0, len(shipments))
+	for _, s := range shipments {
+		lines = append(lines, fmt.Sprintf("%s|%g|%g|%s|%s|%d", s.ID, s.WeightKg, s.VolumeM3, s.DestinationCity, s.Date.Format("2006-01-02"), s.Pallets))
+	}
+	writeSection(h, "shipments", lines)
+
+	lines = make([]string, 0, len(terminals))
+	for _, t := range terminals {
+		lines = append(lines, fmt.Sprintf("%s|%s|%d", t.City, t.Direction, t.DistanceFromMoscowKm))
+	}
+	writeSection(h, "terminals", lines)
+
+	lines = make([]string, 0, len(distances))
+	for _, d := range distances {
+		lines = append(lines, fmt.Sprintf("%s|%s|%d", d.FromCity, d.ToCity, d.Km))
+	}
+	writeSection(h, "distances", lines)
+
+	lines = make([]string, 0, len(interCityRates))
+	for _, r := range interCityRates {
+		lines = append(lines, fmt.Sprintf("%g|%g|%g", r.VolumeM3, r.WeightTons, r.RatePerKm))
+	}
+	writeSection(h, "inter_city_rates", lines)
+
+	lines = make([]string, 0, len(intraCityRates))
+	for _, r := range intraCityRates {
+		lines = append(lines, fmt.Sprintf("%g|%g|%g", r.VolumeM3, r.WeightTons, r.RateFixed))
+	}
+	writeSection(h, "intra_city_rates", lines)
+
+	lines = make([]string, 0, len(fleet))
+	for _, v := range fleet {
+		lines = append(lines, fmt.Sprintf("%s|%g|%g|%d|%g|%g", v.ID, v.CapacityTons, v.CapacityM3, v.CapacityPallets, v.CostPerKm, v.CostPerTrip))
+	}
+	writeSection(h, "vehicles", lines)
+
+	lines = make([]string, 0, len(availability))
+	for _, a := range availability {
+		lines = append(lines, fmt.Sprintf("%s|%s|%d", a.VehicleID, a.DeliveryDay, a.Count))
+	}
+	writeSection(h, "fleet_availability", lines)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeSection(w io.Writer, name string, lines []string) {
+	sort.Strings(lines)
+	fmt.Fprintf(w, "[%s]\n", name)
+	for _, l := range lines {
+		fmt.Fprintln(w, l)
+	}
+}
@@ -0,0 +1,47 @@
+package optimizer
+
+import (
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/ga_level2"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
+)
+
+// fleetLimits — лимиты автопарка на день отгрузки.
+func fleetLimits(availability []models.FleetAvailability, deliveryDay string) map[string]int {
+	limits := make(map[string]int)
+	for _, a := range availability {
+		if a.DeliveryDay == deliveryDay {
+			limits[a.VehicleID] = a.Count
+		}
+	}
+	return limits
+}
+
+// fleetUsage считает рейсы плана по классам ТС и сравнивает с лимитами дня.
+// В отчёт попадают классы, которые использованы или ограничены, в порядке справочника.
+func fleetUsage(routes []ga_level2.RouteWithShipments, fleet logic.Fleet) []*proto.FleetUsage {
+	used := make(map[string]int)
+	for _, r := range routes {
+		used[r.VehicleID]++
+	}
+
+	usage := make([]*proto.FleetUsage, 0)
+	for _, v := range fleet.Vehicles {
+		limit, limited := fleet.Limits[v.ID]
+		if used[v.ID] == 0 && !limited {
+			continue
+		}
+		u := &proto.FleetUsage{
+			VehicleId: v.ID,
+			Used:      int32(used[v.ID]),
+			Limited:   limited,
+		}
+		if limited {
+			u.Available = int32(limit)
+			u.Shortage = int32(max(0, used[v.ID]-limit))
+		}
+		usage = append(usage, u)
+	}
+	return usage
+}
@@ -0,0 +1,58 @@
+package optimizer
+
+import (
+	"testing"
+
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/ga_level2"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
+)
+
+func TestFleetLimits(t *testing.T) {
+	availability := []models.FleetAvailability{
+		{VehicleID: "3t", DeliveryDay: "mon", Count: 2},
+		{VehicleID: "20t", DeliveryDay: "mon", Count: 0},
+		{VehicleID: "3t", DeliveryDay: "tue", Count: 5},
+	}
+	limits := fleetLimits(availability, "mon")
+	if len(limits) != 2 || limits["3t"] != 2 || limits["20t"] != 0 {
+		t.Errorf("fleetLimits(mon) = %v, want map[20t:0 3t:2]", limits)
+	}
+	if limits := fleetLimits(availability, "wed"); len(limits) != 0 {
+		t.Errorf("fleetLimits(wed) = %v, want no limits", limits)
+	}
+}
+
+func TestFleetUsage(t *testing.T) {
+	fleet := logic.Fleet{
+		Vehicles: []models.Vehicle{{ID: "1.5t"}, {ID: "3t"}, {ID: "10t"}, {ID: "20t"}},
+		Limits:   map[string]int{"3t": 1, "10t": 2},
+	}
+	routes := []ga_level2.RouteWithShipments{
+		{ToTerminal: "A", VehicleID: "20t"},
+		{ToTerminal: "A", VehicleID: "3t"},
+		{ToTerminal: "B", VehicleID: "3t"},
+	}
+
+	type row struct {
+		id                        string
+		used, available, shortage int32
+		limited                   bool
+	}
+	want := []row{
+		{id: "3t", used: 2, available: 1, shortage: 1, limited: true},
+		{id: "10t", used: 0, available: 2, limited: true},
+		{id: "20t", used: 1},
+	}
+
+	usage := fleetUsage(routes, fleet)
+	if len(usage) != len(want) {
+		t.Fatalf("fleetUsage() returned %d classes, want %d: %v", len(usage), len(want), usage)
+	}
+	for i, u := range usage {
+		got := row{u.VehicleId, u.Used, u.Available, u.Shortage, u.Limited}
+		if got != want[i] {
+			t.Errorf("usage[%d] = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package ga_level1
+
+import (
+	"context"
+	"fmt"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
+)
+
+// MaxExhaustiveTerminals — при большем числе терминалов полный перебор (2^n наборов) слишком долог.
+const MaxExhaustiveTerminals = 20
+
+// exhaustiveBatch — сколько наборов терминалов полного перебора оценивается за раз.
+const exhaustiveBatch = 1024
+
+// RunBaseline выбирает набор терминалов точным или жадным алгоритмом на той же функции стоимости,
+// что и ГА (CalculateFitness), с учётом ограничений на набор терминалов. Используется как эталон для ГА.
+func RunBaseline(
+	ctx context.Context,
+	algorithm proto.Algorithm,
+	workers int,
+	terminals []models.Terminal,
+	constraints Constraints,
+	shipments []models.Shipment,
+	interCityRates []models.InterCityRate,
+	intraCityRates []models.IntraCityRate,
+	distances map[string]map[string]int,
+	fleet logic.Fleet,
+) (*Individual, error) {
+	e := &evaluator{
+		workers:        workers,
+		terminals:      terminals,
+		shipments:      shipments,
+		interCityRates: interCityRates,
+		intraCityRates: intraCityRates,
+		distances:      distances,
+		fleet:          fleet,
+	}
+	if len(constraints.mustOpen) != len(terminals) {
+		constraints = NewConstraints(terminals, nil, nil, 0, 0)
+	}
+
+	switch algorithm {
+	case proto.Algorithm_ALGORITHM_EXHAUSTIVE:
+		return exhaustive(ctx, e, constraints)
+	case proto.Algorithm_ALGORITHM_GREEDY_ADD:
+		return greedy(ctx, e, constraints, false)
+	case proto.Algorithm_ALGORITHM_GREEDY_DROP:
+		return greedy(ctx, e, constraints, true)
+	default:
+		return nil, fmt.Errorf("unsupported baseline algorithm: %s", algorithm)
+	}
+}
+
+// exhaustive перебирает все наборы терминалов, удовлетворяющие ограничениям.
+// При равной стоимости остаётся набор, встреченный первым.
+func exhaustive(ctx context.Context, e *evaluator, constraints Constraints) (*Individual, error) {
+	n := len(e.terminals)
+	if n > MaxExhaustiveTerminals {
+		return nil, fmt.Errorf("exhaustive search supports at most %d terminals, got %d", MaxExhaustiveTerminals, n)
+	}
+
+	var best *Individual
+	batch := make([]*Individual, 0, exhaustiveBatch)
+	flush := func() error {
+		if err := e.evaluate(ctx, batch); err != nil {
+			return err
+		}
+		for _, ind := range batch {
+			if best == nil || ind.Fitness < best.Fitness {
+				best = ind
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for bits := 1; bits < 1<<n; bits++ {
+		mask := make([]bool, n)
+		for i := range mask {
+			mask[i] = bits&(1<<i) != 0
+		}
+		if !constraints.Satisfied(mask) {
+			continue
+		}
+		batch = append(batch, &Individual{TerminalMask: mask})
+		if len(batch) == exhaustiveBatch {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("no terminal set satisfies the constraints")
+	}
+	return best, nil
+}
+
+// greedy начинает с минимального (drop=false) или максимального (drop=true) допустимого набора
+// и на каждом шаге открывает (закрывает) терминал, сильнее всего снижающий стоимость. Останавливается,
+// когда ни один ход не снижает стоимость, но сначала доводит число терминалов до границ ограничений.
+func greedy(ctx context.Context, e *evaluator, constraints Constraints, drop bool) (*Individual, error) {
+	n := len(e.terminals)
+	start := make([]bool, n)
+	for i := range start {
+		switch {
+		case constraints.mustOpen[i]:
+			start[i] = true
+		case constraints.mustClose[i]:
+			start[i] = false
+		default:
+			start[i] = drop
+		}
+	}
+
+	current := &Individual{TerminalMask: start}
+	if err := e.evaluate(ctx, []*Individual{current}); err != nil {
+		return nil, err
+	}
+
+	for {
+		candidates := make([]*Individual, 0, n)
+		for i, on := range current.TerminalMask {
+			if on != drop || constraints.mustOpen[i] || constraints.mustClose[i] {
+				continue
+			}
+			mask := append([]bool(nil), current.TerminalMask...)
+			mask[i] = !drop
+			candidates = append(candidates, &Individual{TerminalMask: mask})
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		if err := e.evaluate(ctx, candidates); err != nil {
+			return nil, err
+		}
+
+		best := candidates[0]
+		for _, c := range candidates[1:] {
+			if c.Fitness < best.Fitness {
+				best = c
+			}
+		}
+
+		// Ход обязателен, пока набор не укладывается в min/max_active_terminals, и запрещён на их границе
+		open := 0
+		for _, on := range current.TerminalMask {
+			if on {
+				open++
+			}
+		}
+		if (!drop && open >= constraints.maxActive) || (drop && open <= constraints.minActive) {
+			break
+		}
+		forced := (!drop && open < constraints.minActive) || (drop && open > constraints.maxActive)
+		if !forced && best.Fitness >= current.Fitness {
+			break
+		}
+		current = best
+	}
+	return current, nil
+}
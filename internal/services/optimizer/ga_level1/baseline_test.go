@@ -0,0 +1,120 @@
+package ga_level1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+)
+
+func (d testDay) baseline(t *testing.T, algorithm proto.Algorithm, constraints Constraints) *Individual {
+	t.Helper()
+	best, err := RunBaseline(context.Background(), algorithm, 4, d.terminals, constraints, d.shipments,
+		d.interCityRates, d.intraCityRates, d.distances, d.fleet)
+	if err != nil {
+		t.Fatalf("RunBaseline(%s): %v", algorithm, err)
+	}
+	return best
+}
+
+func TestExhaustiveIsNoWorseThanOtherSolvers(t *testing.T) {
+	d := newTestDay()
+	exact := d.baseline(t, proto.Algorithm_ALGORITHM_EXHAUSTIVE, Constraints{})
+
+	for seed := int64(1); seed <= 5; seed++ {
+		if ga := d.run(t, testSettings(), seed).Best; ga.Fitness < exact.Fitness {
+			t.Errorf("seed %d: GA fitness %v is below exhaustive optimum %v", seed, ga.Fitness, exact.Fitness)
+		}
+	}
+	for _, algorithm := range []proto.Algorithm{proto.Algorithm_ALGORITHM_GREEDY_ADD, proto.Algorithm_ALGORITHM_GREEDY_DROP} {
+		if greedy := d.baseline(t, algorithm, Constraints{}); greedy.Fitness < exact.Fitness {
+			t.Errorf("%s fitness %v is below exhaustive optimum %v", algorithm, greedy.Fitness, exact.Fitness)
+		}
+	}
+}
+
+func TestBaselineRespectsConstraints(t *testing.T) {
+	d := newTestDay()
+	tests := []struct {
+		name        string
+		constraints Constraints
+	}{
+		{"pinned", NewConstraints(d.terminals, []string{"T7"}, []string{"T3", "T4"}, 0, 0)},
+		{"at least three", NewConstraints(d.terminals, nil, nil, 3, 0)},
+		{"at most two", NewConstraints(d.terminals, []string{"T0"}, nil, 0, 2)},
+	}
+	for _, tt := range tests {
+		for _, algorithm := range []proto.Algorithm{
+			proto.Algorithm_ALGORITHM_EXHAUSTIVE,
+			proto.Algorithm_ALGORITHM_GREEDY_ADD,
+			proto.Algorithm_ALGORITHM_GREEDY_DROP,
+		} {
+			t.Run(fmt.Sprintf("%s/%s", tt.name, algorithm), func(t *testing.T) {
+				best := d.baseline(t, algorithm, tt.constraints)
+				if !tt.constraints.Satisfied(best.TerminalMask) {
+					t.Errorf("solution %v violates constraints", best.TerminalMask)
+				}
+			})
+		}
+	}
+}
+
+func TestGreedyStopsAtLocalOptimum(t *testing.T) {
+	d := newTestDay()
+	for _, drop := range []bool{false, true} {
+		t.Run(fmt.Sprintf("drop=%v", drop), func(t *testing.T) {
+			e := d.evaluator(4, nil)
+			best, err := greedy(context.Background(), e, NewConstraints(d.terminals, nil, nil, 0, 0), drop)
+			if err != nil {
+				t.Fatalf("greedy: %v", err)
+			}
+
+			// Ни один следующий ход того же направления не снижает стоимость
+			for i, on := range best.TerminalMask {
+				if on != drop {
+					continue
+				}
+				mask := append([]bool(nil), best.TerminalMask...)
+				mask[i] = !drop
+				next := &Individual{TerminalMask: mask}
+				if err := e.evaluate(context.Background(), []*Individual{next}); err != nil {
+					t.Fatal(err)
+				}
+				if next.Fitness < best.Fitness {
+					t.Errorf("move on terminal %d improves %v to %v", i, best.Fitness, next.Fitness)
+				}
+			}
+		})
+	}
+}
+
+func TestBaselineErrors(t *testing.T) {
+	d := newTestDay()
+	many := make([]models.Terminal, MaxExhaustiveTerminals+1)
+	for i := range many {
+		many[i] = models.Terminal{City: fmt.Sprintf("X%d", i)}
+	}
+
+	tests := []struct {
+		name        string
+		algorithm   proto.Algorithm
+		terminals   []models.Terminal
+		constraints Constraints
+	}{
+		{"too many terminals for exhaustive", proto.Algorithm_ALGORITHM_EXHAUSTIVE, many, Constraints{}},
+		{"no feasible set", proto.Algorithm_ALGORITHM_EXHAUSTIVE, d.terminals,
+			NewConstraints(d.terminals, []string{"T0", "T1", "T2"}, nil, 0, 2)},
+		{"unsupported algorithm", proto.Algorithm_ALGORITHM_GA, d.terminals, Constraints{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RunBaseline(context.Background(), tt.algorithm, 4, tt.terminals, tt.constraints, d.shipments,
+				d.interCityRates, d.intraCityRates, d.distances, d.fleet)
+			if err == nil {
+				t.Error("RunBaseline succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package ga_level1
+
+import (
+	"container/list"
+	"sync"
+)
+
+// FitnessCache — LRU-кэш результатов CalculateFitness по маске терминалов для одного набора терминалов,
+// грузов, тарифов и автопарка. Нулевой указатель — кэш отключён.
+type FitnessCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // Спереди — последние использованные
+	hits    int64
+	misses  int64
+}
+
+type cacheEntry struct {
+	key             string
+	fitness         float64
+	cost            CostBreakdown
+	activeTerminals []string
+	routes          []RouteWithShipments
+	avgDistanceKm   float64
+}
+
+// CacheStats — обращения к кэшу за прогон.
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// NewFitnessCache создаёт кэш на size масок. При size <= 0 возвращает nil (без кэша).
+func NewFitnessCache(size int) *FitnessCache {
+	if size <= 0 {
+		return nil
+	}
+	return &FitnessCache{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+func (c *FitnessCache) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses}
+}
+
+// load заполняет оценку особи из кэша; маршруты общие для всех особей с этой маской и не изменяются.
+func (c *FitnessCache) load(key string, ind *Individual) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return false
+	}
+	c.hits++
+	c.order.MoveToFront(el)
+
+	e := el.Value.(*cacheEntry)
+	ind.Fitness = e.fitness
+	ind.Cost = e.cost
+	ind.ActiveTerminals = e.activeTerminals
+	ind.Routes = e.routes
+	ind.AvgDistanceKm = e.avgDistanceKm
+	return true
+}
+
+func (c *FitnessCache) store(key string, ind *Individual) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{
+		key:             key,
+		fitness:         ind.Fitness,
+		cost:            ind.Cost,
+		activeTerminals: ind.ActiveTerminals,
+		routes:          ind.Routes,
+		avgDistanceKm:   ind.AvgDistanceKm,
+	})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// maskKey упаковывает маску терминалов по 8 генов в байт.
+func maskKey(mask []bool) string {
+	packed := make([]byte, (len(mask)+7)/8)
+	for i, open := range mask {
+		if open {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+	return string(packed)
+}
@@ -0,0 +1,110 @@
+package ga_level1
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestFitnessCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewFitnessCache(2)
+	a, b, c := maskKey([]bool{true}), maskKey([]bool{false, true}), maskKey([]bool{true, true})
+	cache.store(a, &Individual{Fitness: 1})
+	cache.store(b, &Individual{Fitness: 2})
+
+	var ind Individual
+	if !cache.load(a, &ind) || ind.Fitness != 1 {
+		t.Fatalf("load(a) = %v, want fitness 1", ind.Fitness)
+	}
+	cache.store(c, &Individual{Fitness: 3}) // вытесняет b: a использована позже
+	if cache.load(b, &ind) {
+		t.Error("b is still cached after eviction")
+	}
+	if !cache.load(a, &ind) || !cache.load(c, &ind) {
+		t.Error("a or c evicted instead of b")
+	}
+	if got, want := cache.Stats(), (CacheStats{Hits: 3, Misses: 1}); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFitnessCacheDisabled(t *testing.T) {
+	if cache := NewFitnessCache(0); cache != nil {
+		t.Fatalf("NewFitnessCache(0) = %v, want nil", cache)
+	}
+	if got := (*FitnessCache)(nil).Stats(); got != (CacheStats{}) {
+		t.Errorf("nil cache Stats() = %+v, want zero", got)
+	}
+}
+
+func TestEvaluateWithCache(t *testing.T) {
+	d := newTestDay()
+	m1 := []bool{true, false, false, true, false, false, false, false}
+	m2 := []bool{false, true, false, false, false, true, false, false}
+	want := make(map[string]float64)
+	for _, m := range [][]bool{m1, m2} {
+		ind := &Individual{TerminalMask: m}
+		if err := CalculateFitness(ind, d.terminals, d.shipments, d.interCityRates, d.intraCityRates, d.distances, d.fleet); err != nil {
+			t.Fatal(err)
+		}
+		want[maskKey(m)] = ind.Fitness
+	}
+
+	cache := NewFitnessCache(10)
+	evalAndCheck := func(masks ...[]bool) {
+		t.Helper()
+		individuals := make([]*Individual, len(masks))
+		for i, m := range masks {
+			individuals[i] = &Individual{TerminalMask: m}
+		}
+		if err := d.evaluator(2, cache).evaluate(context.Background(), individuals); err != nil {
+			t.Fatalf("evaluate: %v", err)
+		}
+		for i, ind := range individuals {
+			if ind.Fitness != want[maskKey(ind.TerminalMask)] || len(ind.Routes) == 0 {
+				t.Errorf("individual %d: fitness %v with %d routes, want %v", i, ind.Fitness, len(ind.Routes), want[maskKey(ind.TerminalMask)])
+			}
+		}
+	}
+
+	// Повторы внутри поколения считаются один раз и не попадают в счётчики
+	evalAndCheck(m1, m1, m2, m1)
+	if got, want := cache.Stats(), (CacheStats{Misses: 2}); got != want {
+		t.Errorf("after first generation Stats() = %+v, want %+v", got, want)
+	}
+	evalAndCheck(m2, m1, m2)
+	if got, want := cache.Stats(), (CacheStats{Hits: 2, Misses: 2}); got != want {
+		t.Errorf("after second generation Stats() = %+v, want %+v", got, want)
+	}
+}
+
+// Повторы маски получают оценку, даже если параллельная оценка вытеснила маску из общего кэша.
+func TestEvaluateDuplicatesWithConcurrentEviction(t *testing.T) {
+	d := newTestDay()
+	masks := [][]bool{
+		{true, false, false, true, false, false, false, false},
+		{false, true, false, false, false, true, false, false},
+	}
+	cache := NewFitnessCache(1)
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 2000 {
+				m := masks[g%2]
+				individuals := []*Individual{{TerminalMask: m}, {TerminalMask: m}}
+				if err := d.evaluator(1, cache).evaluate(context.Background(), individuals); err != nil {
+					t.Error(err)
+					return
+				}
+				if individuals[1].Fitness != individuals[0].Fitness || len(individuals[1].Routes) == 0 {
+					t.Errorf("duplicate fitness %v with %d routes, want %v", individuals[1].Fitness, len(individuals[1].Routes), individuals[0].Fitness)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,127 @@
+package ga_level1
+
+import (
+	"math/rand"
+
+	"noytech-ga-optimizer/internal/models"
+)
+
+// Constraints — ограничения на набор открытых терминалов: закреплённые открытыми и закрытыми
+// терминалы и границы числа открытых. Маски индексируются так же, как список терминалов прогона.
+type Constraints struct {
+	mustOpen  []bool
+	mustClose []bool
+	minActive int
+	maxActive int
+}
+
+// NewConstraints сопоставляет города из ограничений с терминалами прогона. Города вне списка
+// терминалов игнорируются — их отсекает validation.ValidateTerminalConstraints.
+// minActive и maxActive, равные 0, не ограничивают число открытых терминалов.
+func NewConstraints(terminals []models.Terminal, mustOpen, mustClose []string, minActive, maxActive int) Constraints {
+	c := Constraints{
+		mustOpen:  make([]bool, len(terminals)),
+		mustClose: make([]bool, len(terminals)),
+		minActive: minActive,
+		maxActive: maxActive,
+	}
+	if c.maxActive <= 0 || c.maxActive > len(terminals) {
+		c.maxActive = len(terminals)
+	}
+
+	index := make(map[string]int, len(terminals))
+	for i, t := range terminals {
+		index[t.City] = i
+	}
+	opened := 0
+	for _, city := range mustOpen {
+		if i, ok := index[city]; ok && !c.mustOpen[i] {
+			c.mustOpen[i] = true
+			opened++
+		}
+	}
+	closed := 0
+	for _, city := range mustClose {
+		if i, ok := index[city]; ok && !c.mustClose[i] {
+			c.mustClose[i] = true
+			closed++
+		}
+	}
+
+	c.minActive = max(c.minActive, opened)
+	c.maxActive = min(c.maxActive, len(terminals)-closed)
+	return c
+}
+
+// Repair приводит маску к ограничениям: открывает и закрывает закреплённые терминалы, затем
+// закрывает случайные лишние или открывает случайные недостающие незакреплённые терминалы.
+// Маска, которая уже удовлетворяет ограничениям, не меняется, и ГСЧ при этом не используется.
+func (c Constraints) Repair(mask []bool, rng *rand.Rand) {
+	if len(c.mustOpen) != len(mask) {
+		return
+	}
+
+	open := 0
+	for i := range mask {
+		switch {
+		case c.mustOpen[i]:
+			mask[i] = true
+		case c.mustClose[i]:
+			mask[i] = false
+		}
+		if mask[i] {
+			open++
+		}
+	}
+
+	// 1. Лишние терминалы: закрываем случайные незакреплённые
+	if open > c.maxActive {
+		candidates := make([]int, 0, open)
+		for i, on := range mask {
+			if on && !c.mustOpen[i] {
+				candidates = append(candidates, i)
+			}
+		}
+		rng.Shuffle(len(candidates), func(a, b int) {
+			candidates[a], candidates[b] = candidates[b], candidates[a]
+		})
+		for _, i := range candidates[:min(open-c.maxActive, len(candidates))] {
+			mask[i] = false
+		}
+		return
+	}
+
+	// 2. Недостающие терминалы: открываем случайные незакреплённые
+	if open < c.minActive {
+		candidates := make([]int, 0, len(mask)-open)
+		for i, on := range mask {
+			if !on && !c.mustClose[i] {
+				candidates = append(candidates, i)
+			}
+		}
+		rng.Shuffle(len(candidates), func(a, b int) {
+			candidates[a], candidates[b] = candidates[b], candidates[a]
+		})
+		for _, i := range candidates[:min(c.minActive-open, len(candidates))] {
+			mask[i] = true
+		}
+	}
+}
+
+// Satisfied сообщает, что маска удовлетворяет ограничениям.
+func (c Constraints) Satisfied(mask []bool) bool {
+	if len(c.mustOpen) != len(mask) {
+		return true
+	}
+
+	open := 0
+	for i, on := range mask {
+		if (on && c.mustClose[i]) || (!on && c.mustOpen[i]) {
+			return false
+		}
+		if on {
+			open++
+		}
+	}
+	return open >= c.minActive && open <= c.maxActive
+}
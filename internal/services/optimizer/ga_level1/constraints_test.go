@@ -0,0 +1,91 @@
+package ga_level1
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"noytech-ga-optimizer/internal/models"
+)
+
+func TestConstraintsRepair(t *testing.T) {
+	terminals := []models.Terminal{{City: "A"}, {City: "B"}, {City: "C"}, {City: "D"}, {City: "E"}, {City: "F"}}
+	masks := map[string][]bool{
+		"all closed": {false, false, false, false, false, false},
+		"all open":   {true, true, true, true, true, true},
+		"mixed":      {true, false, true, false, true, false},
+		"one open":   {false, false, false, false, false, true},
+	}
+
+	tests := []struct {
+		name       string
+		mustOpen   []string
+		mustClose  []string
+		minActive  int
+		maxActive  int
+		wantMinMax [2]int
+		// conflicting — закреплённые терминалы не дают уложиться в границы; такие запросы
+		// отклоняет валидация, а Repair сохраняет закрепления
+		conflicting bool
+	}{
+		{"no limits", nil, nil, 0, 0, [2]int{0, 6}, false},
+		{"must open", []string{"A", "C"}, nil, 0, 0, [2]int{2, 6}, false},
+		{"must close", nil, []string{"B", "F"}, 0, 0, [2]int{0, 4}, false},
+		{"min", nil, nil, 3, 0, [2]int{3, 6}, false},
+		{"max", nil, nil, 0, 2, [2]int{0, 2}, false},
+		{"min equals max", nil, nil, 3, 3, [2]int{3, 3}, false},
+		{"all together", []string{"A"}, []string{"B", "C"}, 2, 3, [2]int{2, 3}, false},
+		{"must open above max", []string{"A", "B", "C"}, nil, 0, 2, [2]int{3, 3}, true},
+		{"must close below min", nil, []string{"A", "B", "C", "D"}, 4, 0, [2]int{0, 2}, true},
+		{"unknown cities are ignored", []string{"X"}, []string{"Y"}, 1, 1, [2]int{1, 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConstraints(terminals, tt.mustOpen, tt.mustClose, tt.minActive, tt.maxActive)
+			for maskName, initial := range masks {
+				for seed := int64(1); seed <= 20; seed++ {
+					mask := slices.Clone(initial)
+					c.Repair(mask, rand.New(rand.NewSource(seed)))
+
+					open := 0
+					for i, on := range mask {
+						city := terminals[i].City
+						if slices.Contains(tt.mustOpen, city) && !on {
+							t.Errorf("%s, seed %d: must_open %s is closed: %v", maskName, seed, city, mask)
+						}
+						if slices.Contains(tt.mustClose, city) && on {
+							t.Errorf("%s, seed %d: must_close %s is open: %v", maskName, seed, city, mask)
+						}
+						if on {
+							open++
+						}
+					}
+					if open < tt.wantMinMax[0] || open > tt.wantMinMax[1] {
+						t.Errorf("%s, seed %d: %d terminals open, want %d..%d: %v",
+							maskName, seed, open, tt.wantMinMax[0], tt.wantMinMax[1], mask)
+					}
+					if !tt.conflicting && !c.Satisfied(mask) {
+						t.Errorf("%s, seed %d: repaired mask %v is not satisfied", maskName, seed, mask)
+					}
+
+					again := slices.Clone(mask)
+					c.Repair(again, rand.New(rand.NewSource(seed+1)))
+					if !slices.Equal(again, mask) {
+						t.Errorf("%s, seed %d: Repair changed a satisfied mask %v to %v", maskName, seed, mask, again)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestConstraintsRepairChangesOnlyWhatIsNeeded(t *testing.T) {
+	terminals := []models.Terminal{{City: "A"}, {City: "B"}, {City: "C"}, {City: "D"}}
+	c := NewConstraints(terminals, []string{"A"}, []string{"D"}, 0, 3)
+
+	mask := []bool{false, true, false, true}
+	c.Repair(mask, rand.New(rand.NewSource(1)))
+	if want := []bool{true, true, false, false}; !slices.Equal(mask, want) {
+		t.Errorf("Repair = %v, want %v", mask, want)
+	}
+}
@@ -0,0 +1,148 @@
+package ga_level1
+
+import (
+	"context"
+	"math/rand"
+
+	"noytech-ga-optimizer/api/proto"
+)
+
+// maxDuplicateRetries — сколько раз потомок-повтор изменяется, прежде чем его оставляют как есть.
+const maxDuplicateRetries = 10
+
+// diversity — средняя доля различающихся генов у пары особей (среднее расстояние Хэмминга на длину маски):
+// 0 — все особи одинаковые.
+func diversity(individuals []*Individual) float64 {
+	n := len(individuals)
+	if n < 2 || len(individuals[0].TerminalMask) == 0 {
+		return 0
+	}
+
+	genes := len(individuals[0].TerminalMask)
+	differing := 0.0
+	for j := 0; j < genes; j++ {
+		open := 0
+		for _, ind := range individuals {
+			if ind.TerminalMask[j] {
+				open++
+			}
+		}
+		differing += float64(open * (n - open))
+	}
+	pairs := float64(n*(n-1)) / 2
+	return differing / pairs / float64(genes)
+}
+
+// hamming — число терминалов, открытых в одной маске и закрытых в другой.
+func hamming(a, b []bool) int {
+	d := 0
+	for i := range a {
+		if a[i] != b[i] {
+			d++
+		}
+	}
+	return d
+}
+
+// sharedCosts — стоимости особей для селекции при fitness sharing: Fitness, умноженный на число ниши
+// m = Σ max(0, 1 - d/radius), где d — доля различающихся терминалов.
+func sharedCosts(individuals []*Individual, radius float64) []float64 {
+	costs := make([]float64, len(individuals))
+	for i, a := range individuals {
+		genes := float64(len(a.TerminalMask))
+		niche := 0.0
+		for _, b := range individuals {
+			if d := float64(hamming(a.TerminalMask, b.TerminalMask)) / genes; d < radius {
+				niche += 1 - d/radius
+			}
+		}
+		costs[i] = a.Fitness * niche
+	}
+	return costs
+}
+
+// eliminateDuplicates меняет случайные терминалы потомков-повторов elite или предыдущих потомков, пока маска
+// не станет уникальной (не больше maxDuplicateRetries раз). Возвращает индексы изменённых потомков.
+func eliminateDuplicates(children, elite []*Individual, constraints Constraints, rng *rand.Rand) []int {
+	seen := make(map[string]bool, len(children)+len(elite))
+	for _, ind := range elite {
+		seen[maskKey(ind.TerminalMask)] = true
+	}
+	var changed []int
+	for i, child := range children {
+		mask := child.TerminalMask
+		key := maskKey(mask)
+		if seen[key] && len(mask) > 0 {
+			changed = append(changed, i)
+		}
+		for try := 0; seen[key] && try < maxDuplicateRetries && len(mask) > 0; try++ {
+			j := rng.Intn(len(mask))
+			mask[j] = !mask[j]
+			constraints.Repair(mask, rng)
+			key = maskKey(mask)
+		}
+		seen[key] = true
+	}
+	return changed
+}
+
+// crowd — детерминированный crowding: потомок остаётся, только если не хуже более похожего на него родителя
+// своей пары, иначе его место занимает копия родителя.
+func crowd(parents, children []*Individual) {
+	survivor := func(parent, child *Individual) *Individual {
+		if child.Fitness <= parent.Fitness {
+			return child
+		}
+		return parent.copyEvaluated()
+	}
+
+	for i := 0; i < len(children); i += 2 {
+		p1 := parents[i]
+		p2 := parents[(i+1)%len(parents)]
+		c1 := children[i]
+		if i+1 == len(children) {
+			closer := p1
+			if hamming(c1.TerminalMask, p2.TerminalMask) < hamming(c1.TerminalMask, p1.TerminalMask) {
+				closer = p2
+			}
+			children[i] = survivor(closer, c1)
+			continue
+		}
+
+		c2 := children[i+1]
+		straight := hamming(p1.TerminalMask, c1.TerminalMask) + hamming(p2.TerminalMask, c2.TerminalMask)
+		crossed := hamming(p1.TerminalMask, c2.TerminalMask) + hamming(p2.TerminalMask, c1.TerminalMask)
+		if straight <= crossed {
+			children[i], children[i+1] = survivor(p1, c1), survivor(p2, c2)
+		} else {
+			children[i], children[i+1] = survivor(p2, c1), survivor(p1, c2)
+		}
+	}
+}
+
+// immigrate заменяет долю immigrant_rate худших особей острова случайными масками, если разнообразие
+// популяции ниже immigrant_threshold.
+func (isl *island) immigrate(ctx context.Context, settings *proto.DiversitySettings, e *evaluator, gen int) error {
+	pop := isl.pop
+	if settings.GetImmigrantRate() <= 0 || diversity(pop.Individuals) >= settings.GetImmigrantThreshold() {
+		return nil
+	}
+
+	count := min(max(int(settings.ImmigrantRate*float64(len(pop.Individuals))), 1), len(pop.Individuals)-1)
+	immigrants := make([]*Individual, count)
+	for i := range immigrants {
+		mask := make([]bool, len(pop.AllTerminals))
+		for j := range mask {
+			mask[j] = isl.rng.Float64() < isl.probs[j]
+		}
+		isl.constraints.Repair(mask, isl.rng)
+		immigrants[i] = &Individual{TerminalMask: mask, Generation: gen}
+	}
+	if err := e.evaluate(ctx, immigrants); err != nil {
+		return err
+	}
+
+	pop.SortByFitness()
+	copy(pop.Individuals[len(pop.Individuals)-count:], immigrants)
+	return nil
+}
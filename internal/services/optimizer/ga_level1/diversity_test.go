@@ -0,0 +1,136 @@
+package ga_level1
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"noytech-ga-optimizer/internal/models"
+)
+
+func masks(bits ...string) []*Individual {
+	individuals := make([]*Individual, len(bits))
+	for i, b := range bits {
+		mask := make([]bool, len(b))
+		for j, c := range b {
+			mask[j] = c == '1'
+		}
+		individuals[i] = &Individual{TerminalMask: mask}
+	}
+	return individuals
+}
+
+func TestDiversity(t *testing.T) {
+	tests := []struct {
+		name  string
+		masks [][]bool
+		want  float64
+	}{
+		{"single individual", [][]bool{{true, false}}, 0},
+		{"identical", [][]bool{{true, false}, {true, false}, {true, false}}, 0},
+		{"complementary", [][]bool{{true, false}, {false, true}}, 1},
+		// Пары: (1,2) различаются в 1 гене из 4, (1,3) — в 3, (2,3) — в 4; среднее 8/3 из 4
+		{"mixed", [][]bool{{true, true, false, false}, {true, true, true, false}, {false, false, false, true}}, 2.0 / 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			individuals := make([]*Individual, len(tt.masks))
+			for i, m := range tt.masks {
+				individuals[i] = &Individual{TerminalMask: m}
+			}
+			if got := diversity(individuals); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("diversity = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSharedCosts(t *testing.T) {
+	individuals := masks("1100", "1100", "0011", "1000")
+	for _, ind := range individuals {
+		ind.Fitness = 100
+	}
+
+	// 1100 делит нишу с повтором и с 1000 (d = 0.25, вклад 0.5); 0011 ни с кем не похож
+	got := sharedCosts(individuals, 0.5)
+	if want := []float64{250, 250, 100, 200}; !slices.Equal(got, want) {
+		t.Errorf("sharedCosts = %v, want %v", got, want)
+	}
+}
+
+func TestEliminateDuplicates(t *testing.T) {
+	elite := masks("1100")
+	children := masks("1100", "0011", "0011", "1010")
+	changed := eliminateDuplicates(children, elite, Constraints{}, rand.New(rand.NewSource(1)))
+
+	if want := []int{0, 2}; !slices.Equal(changed, want) {
+		t.Errorf("changed = %v, want %v", changed, want)
+	}
+	seen := map[string]bool{maskKey(elite[0].TerminalMask): true}
+	for i, child := range children {
+		key := maskKey(child.TerminalMask)
+		if seen[key] {
+			t.Errorf("child %d %v is still a duplicate", i, child.TerminalMask)
+		}
+		seen[key] = true
+	}
+	if !slices.Equal(children[1].TerminalMask, []bool{false, false, true, true}) ||
+		!slices.Equal(children[3].TerminalMask, []bool{true, false, true, false}) {
+		t.Error("unique children were changed")
+	}
+}
+
+func TestEliminateDuplicatesGivesUp(t *testing.T) {
+	// Единственный терминал закреплён открытым: повтор нельзя сделать уникальным
+	constraints := NewConstraints([]models.Terminal{{City: "A"}}, []string{"A"}, nil, 0, 0)
+	children := masks("1")
+	changed := eliminateDuplicates(children, masks("1"), constraints, rand.New(rand.NewSource(1)))
+
+	if !slices.Equal(changed, []int{0}) || !children[0].TerminalMask[0] {
+		t.Errorf("changed = %v, mask = %v, want [0] and the pinned mask", changed, children[0].TerminalMask)
+	}
+}
+
+func TestCrowd(t *testing.T) {
+	parents := masks("1100", "0011", "1111")
+	parents[0].Fitness, parents[1].Fitness, parents[2].Fitness = 100, 100, 50
+
+	tests := []struct {
+		name     string
+		children []string
+		fitness  []float64
+		// want — fitness выживших; copied — на месте потомка копия родителя
+		want   []float64
+		copied []bool
+	}{
+		{"straight pairing", []string{"1101", "0010"}, []float64{90, 120}, []float64{90, 100}, []bool{false, true}},
+		{"crossed pairing", []string{"0010", "1101"}, []float64{120, 90}, []float64{100, 90}, []bool{true, false}},
+		// Третий потомок без пары соревнуется с более похожим родителем — 1111
+		{"odd child", []string{"1101", "0010", "0111"}, []float64{90, 120, 200}, []float64{90, 100, 50}, []bool{false, true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			children := masks(tt.children...)
+			original := slices.Clone(children)
+			for i, c := range children {
+				c.Fitness = tt.fitness[i]
+			}
+			crowd(parents, children)
+
+			for i, c := range children {
+				if c.Fitness != tt.want[i] {
+					t.Errorf("child %d fitness = %v, want %v", i, c.Fitness, tt.want[i])
+				}
+				if copied := c != original[i]; copied != tt.copied[i] {
+					t.Errorf("child %d replaced = %v, want %v", i, copied, tt.copied[i])
+				}
+				for _, p := range parents {
+					if c == p || (len(c.TerminalMask) > 0 && &c.TerminalMask[0] == &p.TerminalMask[0]) {
+						t.Errorf("child %d shares a parent or its mask", i)
+					}
+				}
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package ga_level1
 
 import (
 	"math"
-	"noytech-ga-optimizer/api/proto"
 	"noytech-ga-optimizer/internal/models"
 	"noytech-ga-optimizer/internal/services/optimizer/logic"
 )
@@ -14,6 +13,7 @@ func CalculateFitness(
 	interCityRates []models.InterCityRate,
 	intraCityRates []models.IntraCityRate,
 	distances map[string]map[string]int,
+	fleet logic.Fleet,
 ) error {
 	// 1. Активные терминалы
 	activeTerminals := make([]models.Terminal, 0)
@@ -32,6 +32,7 @@ func CalculateFitness(
 	// 2. Распределение грузов по ближайшему терминалу
 	terminalShipments := make(map[string][]models.Shipment)
 	penalty := 0.0
+	totalDistance, assigned := 0, 0
 	for _, s := range shipments {
 		bestCity := ""
 		minDist := math.MaxInt
@@ -48,77 +49,52 @@ func CalculateFitness(
 			continue
 		}
 		terminalShipments[bestCity] = append(terminalShipments[bestCity], s)
+		totalDistance += minDist
+		assigned++
 	}
 
-	// 3. Last-mile cost
+	// 3. Last-mile cost (в порядке activeTerminals, чтобы сумма не зависела от порядка обхода map)
 	lastMileCost := 0.0
-	for city, sList := range terminalShipments {
+	for _, t := range activeTerminals {
 		cost, err := logic.CalculateLastMileCostForTerminal(
-			sList, city, interCityRates, intraCityRates, distances[city])
+			terminalShipments[t.City], t.City, interCityRates, intraCityRates, distances[t.City])
 		if err != nil {
 			return err
 		}
 		lastMileCost += cost
 	}
 
-	// 4. Linehaul cost
+	// 4. Раскладка потока каждого терминала по ТС и linehaul cost.
+	// Терминалы делят автопарк дня в порядке activeTerminals. Каждый рейс оплачивается по тарифу
+	// лайнхола терминала с учётом стоимости класса ТС; терминал без грузов — одним рейсом по тарифу.
 	linehaulCost := 0.0
+	routes := make([]RouteWithShipments, 0)
+	pool := fleet.NewPool()
 	for _, t := range activeTerminals {
-		cost, err := logic.CalculateLinehaulCost(t, activeTerminals, interCityRates)
+		terminalRate, err := logic.CalculateLinehaulCost(t, activeTerminals, interCityRates)
 		if err != nil {
 			return err
 		}
-		linehaulCost += cost
-	}
 
-	// 5. Штрафы и подбор ТС
-	routes := make([]RouteWithShipments, 0)
-	for city, sList := range terminalShipments {
-		totalWeightTons := 0.0
-		totalVolumeM3 := 0.0
-		ids := make([]string, 0)
-		for _, s := range sList {
-			totalWeightTons += s.WeightKg / 1000.0
-			totalVolumeM3 += s.VolumeM3
-			ids = append(ids, s.ID)
+		loads := logic.AllocateVehicles(terminalShipments[t.City], fleet.Vehicles, pool)
+		if len(loads) == 0 {
+			linehaulCost += terminalRate
 		}
 
-		var chosenType proto.TransportType
-		var capTons, capM3 float64
-		found := false
-		for _, tr := range logic.AvailableTransports {
-			if totalWeightTons <= tr.CapTons && totalVolumeM3 <= tr.CapM3 {
-				chosenType = tr.Type
-				capTons = tr.CapTons
-				capM3 = tr.CapM3
-				found = true
-				break
-			}
-		}
+		// 5. Штрафы за негабарит, нехватку ТС и недозагрузку
+		penalty += logic.LoadPenalty(loads)
 
-		if !found {
-			last := logic.AvailableTransports[len(logic.AvailableTransports)-1]
-			chosenType = last.Type
-			capTons = last.CapTons
-			capM3 = last.CapM3
-			penalty += 50000
+		for _, load := range loads {
+			tripCost := logic.TripCost(terminalRate, load.Vehicle, t)
+			linehaulCost += tripCost
+			routes = append(routes, RouteWithShipments{
+				FromCity:    "Москва",
+				ToTerminal:  t.City,
+				ShipmentIDs: load.ShipmentIDs(),
+				Cost:        tripCost,
+				VehicleID:   load.Vehicle.ID,
+			})
 		}
-
-		utilWeight := totalWeightTons / capTons
-		utilVolume := totalVolumeM3 / capM3
-		utilization := math.Max(utilWeight, utilVolume)
-
-		if utilization < 0.6 {
-			penalty += 10000 * (0.6 - utilization)
-		}
-
-		routes = append(routes, RouteWithShipments{
-			FromCity:      "Москва",
-			ToTerminal:    city,
-			ShipmentIDs:   ids,
-			Cost:          0,
-			TransportUsed: chosenType,
-		})
 	}
 
 	totalCost := linehaulCost + lastMileCost + penalty
@@ -129,6 +105,10 @@ func CalculateFitness(
 		TotalCost:    totalCost,
 	}
 	ind.Fitness = totalCost
+	ind.AvgDistanceKm = 0
+	if assigned > 0 {
+		ind.AvgDistanceKm = float64(totalDistance) / float64(assigned)
+	}
 	ind.ActiveTerminals = make([]string, len(activeTerminals))
 	for i, t := range activeTerminals {
 		ind.ActiveTerminals[i] = t.City
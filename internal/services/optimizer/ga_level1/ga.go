@@ -1,60 +1,150 @@
 package ga_level1
 
 import (
+	"context"
+	"math/rand"
+
 	"noytech-ga-optimizer/api/proto"
 	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
 )
 
+// Result — итог прогона ГА 1-го уровня.
+type Result struct {
+	Best        *Individual
+	Front       []*Individual // Недоминируемые решения (только RunNSGA2), Best — первое из них
+	LocalSearch LocalSearchStats
+	TimeLimited bool // Прогон остановлен по max_duration_ms
+
+	StopReason     proto.StopReason // Условие, остановившее прогон
+	StopGeneration int              // Поколение, после которого прогон остановлен
+	Evaluations    int64            // Оценок fitness за прогон, включая попадания в кэш и локальный поиск
+}
+
 func RunGA(
+	ctx context.Context,
 	settings *proto.GASettings,
+	rng *rand.Rand,
+	workers int,
+	cache *FitnessCache,
 	terminals []models.Terminal,
+	constraints Constraints,
+	seeds [][]bool,
 	shipments []models.Shipment,
 	interCityRates []models.InterCityRate,
 	intraCityRates []models.IntraCityRate,
 	distances map[string]map[string]int,
-) (*Individual, error) {
-	pop := NewRandomPopulation(int(settings.NumIndividuals), terminals)
-	if err := pop.Evaluate(shipments, interCityRates, intraCityRates, distances); err != nil {
+	fleet logic.Fleet,
+	onProgress ProgressFunc,
+) (*Result, error) {
+	e := &evaluator{
+		workers:        workers,
+		cache:          cache,
+		terminals:      terminals,
+		shipments:      shipments,
+		interCityRates: interCityRates,
+		intraCityRates: intraCityRates,
+		distances:      distances,
+		fleet:          fleet,
+	}
+
+	in, err := newInitializer(ctx, settings.Init, e, constraints, seeds)
+	if err != nil {
 		return nil, err
 	}
+	islands := newIslands(settings, rng, in, terminals, constraints)
+	for _, isl := range islands {
+		if err := e.evaluate(ctx, isl.pop.Individuals); err != nil {
+			return nil, err
+		}
+	}
+
+	var ls *localSearch
+	if settings.LocalSearch != nil {
+		ls = newLocalSearch(settings.LocalSearch, constraints, e)
+	}
+	localSearchElite := ls != nil && settings.LocalSearch.Scope == proto.LocalSearchScope_LOCAL_SEARCH_ELITE
 
-	best := pop.GetBest()
+	// Поколения выполняются в рамках бюджета времени: по его истечении прогон возвращает лучшее найденное
+	runCtx, cancel := logic.WithBudget(ctx, settings.MaxDurationMs)
+	defer cancel()
+
+	result := &Result{}
+	stop := &stopper{settings: settings, runCtx: runCtx}
+	best := bestOf(islands)
 	noImprove := 0
 
-	for gen := 0; gen < int(settings.NumGenerations); gen++ {
-		currentBest := pop.GetBest()
-		if currentBest.Fitness < best.Fitness {
-			best = currentBest
-			noImprove = 0
-		} else {
-			noImprove++
-		}
+	// Поколение 0 — начальная популяция. Лучшее решение обновляется после каждого поколения, включая последнее
+	for gen := 0; ; gen++ {
+		if gen > 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if ls != nil {
+				ls.generation = gen
+			}
 
-		if noImprove >= int(settings.StoppingCriterion) {
-			break
-		}
+			err := stepIslands(runCtx, islands, settings, e, gen)
+			if err == nil && localSearchElite {
+				err = ls.improveElite(runCtx, islands, max(int(settings.EliteCount), 1))
+			}
+			if err != nil {
+				if !logic.BudgetExceeded(ctx, runCtx) {
+					return nil, err
+				}
+				// Острова, успевшие завершить поколение, уже оценены — их особи тоже учитываются
+				if currentBest := bestOf(islands); currentBest.Fitness < best.Fitness {
+					best = currentBest
+				}
+				result.StopReason, result.StopGeneration = proto.StopReason_STOP_TIME_LIMIT, gen
+				break
+			}
 
-		parents := SelectParents(pop.Individuals, len(pop.Individuals), settings.SelectionType)
-		newPop := make([]*Individual, 0, len(pop.Individuals))
+			if settings.Islands != nil && gen%int(settings.Islands.MigrationInterval) == 0 {
+				migrate(islands, settings.Islands)
+			}
 
-		for i := 0; i < len(parents); i += 2 {
-			p1 := parents[i]
-			p2 := parents[(i+1)%len(parents)]
-			child1, child2 := Crossover(p1, p2, settings.CrossoverType)
-			Mutate(child1, 0.1, settings.MutationType)
-			Mutate(child2, 0.1, settings.MutationType)
-			newPop = append(newPop, child1, child2)
+			if currentBest := bestOf(islands); currentBest.Fitness < best.Fitness {
+				best = currentBest
+				noImprove = 0
+			} else {
+				noImprove++
+			}
 		}
 
-		if len(newPop) > len(pop.Individuals) {
-			newPop = newPop[:len(pop.Individuals)]
+		if onProgress != nil {
+			onProgress(newProgress(individualsOf(islands), best, gen, int(settings.NumGenerations), noImprove))
 		}
 
-		pop.Individuals = newPop
-		if err := pop.Evaluate(shipments, interCityRates, intraCityRates, distances); err != nil {
-			return nil, err
+		reason := stop.check(gen, best, noImprove, individualsOf(islands), e.evaluations.Load())
+		if reason != proto.StopReason_STOP_REASON_UNSPECIFIED {
+			result.StopReason, result.StopGeneration = reason, gen
+			break
 		}
 	}
+	// Отмена запроса, совпавшая с остановкой по бюджету времени, — всё равно отмена
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
-	return best, nil
+	result.Best = best
+	result.TimeLimited = result.StopReason == proto.StopReason_STOP_TIME_LIMIT
+	if ls != nil {
+		if settings.LocalSearch.Scope == proto.LocalSearchScope_LOCAL_SEARCH_FINAL_BEST && !result.TimeLimited {
+			ls.generation = result.StopGeneration
+			improved, err := ls.improve(runCtx, best)
+			switch {
+			case err == nil:
+				result.Best = improved
+			case logic.BudgetExceeded(ctx, runCtx):
+				result.TimeLimited = true
+			default:
+				return nil, err
+			}
+		}
+		result.LocalSearch = ls.stats
+		result.LocalSearch.BestImprovement = result.Best.LocalSearchGain
+	}
+	result.Evaluations = e.evaluations.Load()
+	return result, nil
 }
@@ -0,0 +1,133 @@
+package ga_level1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
+)
+
+// testDay — восемь терминалов и грузы одного дня отгрузки.
+type testDay struct {
+	terminals      []models.Terminal
+	shipments      []models.Shipment
+	interCityRates []models.InterCityRate
+	intraCityRates []models.IntraCityRate
+	distances      map[string]map[string]int
+	fleet          logic.Fleet
+}
+
+func newTestDay() testDay {
+	d := testDay{
+		interCityRates: []models.InterCityRate{
+			{VolumeM3: 20, WeightTons: 3, RatePerKm: 30},
+			{VolumeM3: 100, WeightTons: 30, RatePerKm: 60},
+		},
+		intraCityRates: []models.IntraCityRate{
+			{VolumeM3: 20, WeightTons: 3, RateFixed: 800},
+			{VolumeM3: 100, WeightTons: 30, RateFixed: 2000},
+		},
+		distances: make(map[string]map[string]int),
+		fleet: logic.Fleet{Vehicles: []models.Vehicle{
+			{ID: "3t", CapacityTons: 3, CapacityM3: 20},
+			{ID: "10t", CapacityTons: 10, CapacityM3: 45},
+			{ID: "20t", CapacityTons: 20, CapacityM3: 86},
+		}},
+	}
+	for k := range 8 {
+		city := fmt.Sprintf("T%d", k)
+		d.terminals = append(d.terminals, models.Terminal{City: city, DistanceFromMoscowKm: 100 + 70*k})
+		d.distances[city] = make(map[string]int)
+		for c := range 10 {
+			d.distances[city][fmt.Sprintf("c%d", c)] = 15 + 25*abs(k-c)
+		}
+	}
+	for i := range 30 {
+		d.shipments = append(d.shipments, models.Shipment{
+			ID:              fmt.Sprintf("s%d", i),
+			WeightKg:        float64(500 + 300*(i%7)),
+			VolumeM3:        float64(2 + i%5),
+			DestinationCity: fmt.Sprintf("c%d", i%10),
+		})
+	}
+	return d
+}
+
+func (d testDay) evaluator(workers int, cache *FitnessCache) *evaluator {
+	return &evaluator{
+		workers:        workers,
+		cache:          cache,
+		terminals:      d.terminals,
+		shipments:      d.shipments,
+		interCityRates: d.interCityRates,
+		intraCityRates: d.intraCityRates,
+		distances:      d.distances,
+		fleet:          d.fleet,
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func testSettings() *proto.GASettings {
+	return &proto.GASettings{
+		NumGenerations:    20,
+		NumIndividuals:    16,
+		SelectionType:     proto.SelectionType_SELECTION_TOURNAMENT,
+		CrossoverType:     proto.CrossoverType_CROSSOVER_UNIFORM,
+		MutationType:      proto.MutationType_MUTATION_INVERSION,
+		StoppingCriterion: 20,
+	}
+}
+
+func (d testDay) run(t *testing.T, settings *proto.GASettings, seed int64) *Result {
+	t.Helper()
+	result, err := RunGA(context.Background(), settings, rand.New(rand.NewSource(seed)), 4, NewFitnessCache(1000), d.terminals, Constraints{}, nil, d.shipments,
+		d.interCityRates, d.intraCityRates, d.distances, d.fleet, nil)
+	if err != nil {
+		t.Fatalf("RunGA: %v", err)
+	}
+	return result
+}
+
+func TestRunGAIsReproducible(t *testing.T) {
+	d := newTestDay()
+	first := d.run(t, testSettings(), 42)
+	second := d.run(t, testSettings(), 42)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("runs with the same seed differ:\n%+v\n%+v", first, second)
+	}
+}
+
+func TestRunGAStopsAtTimeBudget(t *testing.T) {
+	settings := testSettings()
+	settings.NumGenerations = 1 << 30
+	settings.StoppingCriterion = 1 << 30
+	settings.MaxDurationMs = 50
+
+	result := newTestDay().run(t, settings, 1)
+	if !result.TimeLimited || result.Best == nil || len(result.Best.Routes) == 0 {
+		t.Errorf("result = %+v, want time-limited best solution", result)
+	}
+}
+
+func TestRunGAReturnsErrorOnCancel(t *testing.T) {
+	d := newTestDay()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := RunGA(ctx, testSettings(), rand.New(rand.NewSource(1)), 4, nil, d.terminals, Constraints{}, nil, d.shipments,
+		d.interCityRates, d.intraCityRates, d.distances, d.fleet, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RunGA error = %v, want context.Canceled", err)
+	}
+}
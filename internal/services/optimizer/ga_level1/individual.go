@@ -1,21 +1,27 @@
 package ga_level1
 
-import "noytech-ga-optimizer/api/proto"
-
 type Individual struct {
 	TerminalMask    []bool
 	Fitness         float64
 	Cost            CostBreakdown
 	ActiveTerminals []string
 	Routes          []RouteWithShipments
+	AvgDistanceKm   float64 // Среднее расстояние от терминала до получателя по назначенным грузам
+	Generation      int     // Поколение, в котором особь появилась (0 — начальная популяция)
+	LocalSearchGain float64 // Насколько локальный поиск снизил fitness этой особи (потомкам не передаётся)
+
+	// Многокритериальный режим (NSGA-II)
+	Objectives []float64 // Значения целей в порядке GASettings.objectives
+	Rank       int       // Номер фронта Парето, 1 — недоминируемые
+	crowding   float64
 }
 
 type RouteWithShipments struct {
-	FromCity      string
-	ToTerminal    string
-	ShipmentIDs   []string
-	Cost          float64
-	TransportUsed proto.TransportType
+	FromCity    string
+	ToTerminal  string
+	ShipmentIDs []string
+	Cost        float64
+	VehicleID   string // Класс ТС из справочника автопарка
 }
 
 type CostBreakdown struct {
@@ -24,3 +30,29 @@ type CostBreakdown struct {
 	PenaltyCost  float64
 	TotalCost    float64
 }
+
+func byFitness(ind *Individual) float64 {
+	return ind.Fitness
+}
+
+// copyEvaluation переносит в особь результат оценки src с той же маской терминалов.
+func (ind *Individual) copyEvaluation(src *Individual) {
+	ind.Fitness = src.Fitness
+	ind.Cost = src.Cost
+	ind.ActiveTerminals = src.ActiveTerminals
+	ind.Routes = src.Routes
+	ind.AvgDistanceKm = src.AvgDistanceKm
+}
+
+// Clone копирует генотип особи; fitness и маршруты пересчитываются при оценке.
+func (ind *Individual) Clone() *Individual {
+	return &Individual{TerminalMask: append([]bool(nil), ind.TerminalMask...)}
+}
+
+// copyEvaluated копирует оценённую особь вместе с fitness и маршрутами, чтобы копия и оригинал
+// могли одновременно находиться в популяции, не разделяя маску.
+func (ind *Individual) copyEvaluated() *Individual {
+	c := *ind
+	c.TerminalMask = append([]bool(nil), ind.TerminalMask...)
+	return &c
+}
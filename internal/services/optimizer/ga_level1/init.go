@@ -0,0 +1,130 @@
+package ga_level1
+
+import (
+	"context"
+	"math"
+	"math/rand"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+)
+
+// DefaultInitDensity — доля открытых терминалов в случайной начальной маске по умолчанию.
+const DefaultInitDensity = 0.3
+
+// initializer строит начальные популяции 1-го уровня по settings.Init. Каждая популяция начинается
+// с масок seeds, остальные особи — случайные маски с вероятностями probs или, при тёплом старте,
+// копии seeds с мутацией. Все маски приводятся к ограничениям на набор терминалов.
+type initializer struct {
+	probs   []float64 // Вероятность открыть каждый терминал в случайной маске
+	seeds   [][]bool
+	perturb bool
+}
+
+// newInitializer готовит начальные маски стратегии init. seeds — наборы терминалов тёплого старта,
+// уже сопоставленные с терминалами прогона. Жадная стратегия сразу считает решения жадного
+// добавления и удаления через e, поэтому они попадают в кэш fitness прогона.
+func newInitializer(ctx context.Context, init *proto.PopulationInit, e *evaluator, constraints Constraints, seeds [][]bool) (*initializer, error) {
+	density := DefaultInitDensity
+	if init.GetDensity() > 0 {
+		density = init.GetDensity()
+	}
+
+	in := &initializer{probs: make([]float64, len(e.terminals))}
+	for i := range in.probs {
+		in.probs[i] = density
+	}
+
+	switch init.GetStrategy() {
+	case proto.InitStrategy_INIT_GREEDY:
+		for _, drop := range []bool{false, true} {
+			ind, err := greedy(ctx, e, constraints, drop)
+			if err != nil {
+				return nil, err
+			}
+			in.seeds = append(in.seeds, ind.TerminalMask)
+		}
+	case proto.InitStrategy_INIT_DEMAND_WEIGHTED:
+		in.probs = demandWeights(e.terminals, e.shipments, e.distances, density)
+	case proto.InitStrategy_INIT_WARM_START:
+		in.seeds = seeds
+		in.perturb = len(seeds) > 0
+	}
+	return in, nil
+}
+
+// population создаёт популяцию из size особей. Без seeds и с плотностью по умолчанию ГСЧ расходуется
+// так же, как до появления стратегий инициализации, поэтому прежние seed дают прежний результат.
+func (in *initializer) population(size int, terminals []models.Terminal, constraints Constraints, rng *rand.Rand) *Population {
+	pop := &Population{
+		Individuals:  make([]*Individual, size),
+		AllTerminals: terminals,
+	}
+	for i := 0; i < size; i++ {
+		var mask []bool
+		switch {
+		case i < len(in.seeds):
+			mask = append([]bool(nil), in.seeds[i]...)
+		case in.perturb:
+			// Копия набора тёплого старта, в которой в среднем меняется один терминал
+			mask = append([]bool(nil), in.seeds[i%len(in.seeds)]...)
+			for j := range mask {
+				if rng.Float64() < 1/float64(len(mask)) {
+					mask[j] = !mask[j]
+				}
+			}
+		default:
+			mask = make([]bool, len(terminals))
+			for j := range mask {
+				mask[j] = rng.Float32() < float32(in.probs[j])
+			}
+		}
+		constraints.Repair(mask, rng)
+		pop.Individuals[i] = &Individual{TerminalMask: mask}
+	}
+	return pop
+}
+
+// demandWeights распределяет вероятности открытия терминалов пропорционально весу грузов, для которых
+// терминал ближайший среди всех, так что в среднем открыта доля density терминалов. Терминалы без
+// такого спроса изначально закрыты. Без грузов вероятности одинаковые.
+func demandWeights(terminals []models.Terminal, shipments []models.Shipment, distances map[string]map[string]int, density float64) []float64 {
+	demand := make([]float64, len(terminals))
+	total := 0.0
+	for _, s := range shipments {
+		nearest, minDist := -1, math.MaxInt
+		for i, t := range terminals {
+			if d, ok := distances[t.City][s.DestinationCity]; ok && d < minDist {
+				nearest, minDist = i, d
+			}
+		}
+		if nearest >= 0 {
+			demand[nearest] += s.WeightKg
+			total += s.WeightKg
+		}
+	}
+
+	probs := make([]float64, len(terminals))
+	for i := range probs {
+		if total == 0 {
+			probs[i] = density
+			continue
+		}
+		probs[i] = min(density*float64(len(terminals))*demand[i]/total, 1)
+	}
+	return probs
+}
+
+// NewMask сопоставляет набор открытых терминалов (городов) с терминалами прогона.
+// Города вне списка терминалов игнорируются.
+func NewMask(terminals []models.Terminal, cities []string) []bool {
+	open := make(map[string]bool, len(cities))
+	for _, city := range cities {
+		open[city] = true
+	}
+	mask := make([]bool, len(terminals))
+	for i, t := range terminals {
+		mask[i] = open[t.City]
+	}
+	return mask
+}
@@ -0,0 +1,118 @@
+package ga_level1
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+)
+
+func TestDemandWeights(t *testing.T) {
+	terminals := []models.Terminal{{City: "A"}, {City: "B"}, {City: "C"}, {City: "D"}}
+	distances := map[string]map[string]int{
+		"A": {"x": 10, "y": 50},
+		"B": {"x": 40, "y": 20},
+		"C": {"x": 30, "y": 30},
+		"D": {"x": 90, "y": 90},
+	}
+	ship := func(city string, kg float64) models.Shipment {
+		return models.Shipment{DestinationCity: city, WeightKg: kg}
+	}
+
+	tests := []struct {
+		name      string
+		shipments []models.Shipment
+		density   float64
+		want      []float64
+	}{
+		{"no shipments gives equal probabilities", nil, 0.3, []float64{0.3, 0.3, 0.3, 0.3}},
+		{"proportional to nearest demand", []models.Shipment{ship("x", 1000), ship("y", 3000)}, 0.25,
+			[]float64{0.25, 0.75, 0, 0}},
+		{"capped at one", []models.Shipment{ship("x", 3000), ship("y", 1000)}, 0.5,
+			[]float64{1, 0.5, 0, 0}},
+		{"unknown destination is ignored", []models.Shipment{ship("x", 1000), ship("z", 5000)}, 0.25,
+			[]float64{1, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := demandWeights(terminals, tt.shipments, distances, tt.density)
+			for i := range tt.want {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Fatalf("demandWeights = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestInitializer(t *testing.T) {
+	d := newTestDay()
+	warm := [][]bool{NewMask(d.terminals, []string{"T1", "T5"}), NewMask(d.terminals, []string{"T0", "T1", "T6"})}
+	constraints := NewConstraints(d.terminals, []string{"T1"}, []string{"T7"}, 2, 5)
+
+	tests := []struct {
+		name  string
+		init  *proto.PopulationInit
+		seeds [][]bool
+		// first — маски, с которых начинается популяция
+		first [][]bool
+	}{
+		{"random", nil, nil, nil},
+		{"random with density", &proto.PopulationInit{Strategy: proto.InitStrategy_INIT_RANDOM, Density: 0.8}, nil, nil},
+		{"demand weighted", &proto.PopulationInit{Strategy: proto.InitStrategy_INIT_DEMAND_WEIGHTED}, nil, nil},
+		{"warm start", &proto.PopulationInit{Strategy: proto.InitStrategy_INIT_WARM_START}, warm, warm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in, err := newInitializer(context.Background(), tt.init, d.evaluator(4, nil), constraints, tt.seeds)
+			if err != nil {
+				t.Fatalf("newInitializer: %v", err)
+			}
+			pop := in.population(20, d.terminals, constraints, rand.New(rand.NewSource(1)))
+			if len(pop.Individuals) != 20 {
+				t.Fatalf("population size %d, want 20", len(pop.Individuals))
+			}
+			for i, ind := range pop.Individuals {
+				if !constraints.Satisfied(ind.TerminalMask) {
+					t.Errorf("individual %d %v violates constraints", i, ind.TerminalMask)
+				}
+				if i < len(tt.first) && !slices.Equal(ind.TerminalMask, tt.first[i]) {
+					t.Errorf("individual %d = %v, want seed %v", i, ind.TerminalMask, tt.first[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInitializerGreedySeeds(t *testing.T) {
+	d := newTestDay()
+	e := d.evaluator(4, nil)
+	constraints := NewConstraints(d.terminals, nil, nil, 0, 0)
+
+	in, err := newInitializer(context.Background(), &proto.PopulationInit{Strategy: proto.InitStrategy_INIT_GREEDY}, e, constraints, nil)
+	if err != nil {
+		t.Fatalf("newInitializer: %v", err)
+	}
+	pop := in.population(10, d.terminals, constraints, rand.New(rand.NewSource(1)))
+	for i, drop := range []bool{false, true} {
+		want, err := greedy(context.Background(), e, constraints, drop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(pop.Individuals[i].TerminalMask, want.TerminalMask) {
+			t.Errorf("individual %d = %v, want greedy (drop=%v) %v", i, pop.Individuals[i].TerminalMask, drop, want.TerminalMask)
+		}
+	}
+}
+
+func TestNewMask(t *testing.T) {
+	terminals := []models.Terminal{{City: "A"}, {City: "B"}, {City: "C"}}
+	got := NewMask(terminals, []string{"C", "X", "A"})
+	if want := []bool{true, false, true}; !slices.Equal(got, want) {
+		t.Errorf("NewMask = %v, want %v", got, want)
+	}
+}
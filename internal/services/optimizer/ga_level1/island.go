@@ -0,0 +1,204 @@
+package ga_level1
+
+import (
+	"context"
+	"math/rand"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
+)
+
+// island — популяция островной модели со своими операторами и ГСЧ.
+// Без островной модели прогон состоит из одного острова с операторами из GASettings.
+type island struct {
+	pop         *Population
+	selection   proto.SelectionType
+	crossover   proto.CrossoverType
+	mutation    proto.MutationType
+	constraints Constraints
+	probs       []float64 // Вероятности открытия терминалов в случайной маске (settings.Init)
+	rng         *rand.Rand
+}
+
+// newIslands создаёт популяции прогона. Единственный остров использует ГСЧ прогона, а при нескольких
+// островах каждый получает собственный ГСЧ, порождённый от него, — так результат не зависит от того,
+// в каком порядке острова выполняются параллельно.
+func newIslands(settings *proto.GASettings, rng *rand.Rand, in *initializer, terminals []models.Terminal, constraints Constraints) []*island {
+	count := 1
+	if settings.Islands != nil {
+		count = int(settings.Islands.Count)
+	}
+
+	islands := make([]*island, count)
+	for i := range islands {
+		isl := &island{
+			selection:   settings.SelectionType,
+			crossover:   settings.CrossoverType,
+			mutation:    settings.MutationType,
+			constraints: constraints,
+			probs:       in.probs,
+			rng:         rng,
+		}
+		if count > 1 {
+			isl.rng = rand.New(rand.NewSource(rng.Int63()))
+			if ops := settings.Islands.Operators; len(ops) > 0 {
+				isl.override(ops[i%len(ops)])
+			}
+		}
+		isl.pop = in.population(int(settings.NumIndividuals), terminals, constraints, isl.rng)
+		islands[i] = isl
+	}
+	return islands
+}
+
+// override заменяет операторы острова указанными в ops; неуказанные остаются из GASettings.
+func (isl *island) override(ops *proto.IslandOperators) {
+	if ops.GetSelectionType() != proto.SelectionType_SELECTION_UNSPECIFIED {
+		isl.selection = ops.SelectionType
+	}
+	if ops.GetCrossoverType() != proto.CrossoverType_CROSSOVER_UNSPECIFIED {
+		isl.crossover = ops.CrossoverType
+	}
+	if ops.GetMutationType() != proto.MutationType_MUTATION_UNSPECIFIED {
+		isl.mutation = ops.MutationType
+	}
+}
+
+// step заменяет популяцию острова поколением gen: элита переходит без изменений,
+// остальные места занимают потомки, приведённые к ограничениям на набор терминалов.
+// Механизмы разнообразия из settings.Diversity применяются здесь же.
+func (isl *island) step(ctx context.Context, settings *proto.GASettings, e *evaluator, gen int) error {
+	pop := isl.pop
+	mutationRate, crossoverRate := logic.Rates(settings)
+
+	elite := logic.Elite(pop.Individuals, int(settings.EliteCount), byFitness)
+	numChildren := len(pop.Individuals) - len(elite)
+
+	div := settings.Diversity
+	var costs []float64
+	if div.GetNiching() == proto.NichingMethod_NICHING_FITNESS_SHARING {
+		costs = sharedCosts(pop.Individuals, div.SharingRadius)
+	}
+
+	parents := SelectParents(pop.Individuals, costs, numChildren, isl.selection, settings, isl.rng)
+	children := make([]*Individual, 0, numChildren+1)
+
+	for i := 0; i < len(parents); i += 2 {
+		p1 := parents[i]
+		p2 := parents[(i+1)%len(parents)]
+		var child1, child2 *Individual
+		if crossoverRate >= 1 || isl.rng.Float64() < crossoverRate {
+			child1, child2 = Crossover(p1, p2, isl.crossover, isl.rng)
+		} else {
+			child1, child2 = p1.Clone(), p2.Clone()
+		}
+		Mutate(child1, mutationRate, isl.mutation, isl.rng)
+		Mutate(child2, mutationRate, isl.mutation, isl.rng)
+		isl.constraints.Repair(child1.TerminalMask, isl.rng)
+		isl.constraints.Repair(child2.TerminalMask, isl.rng)
+		child1.Generation, child2.Generation = gen, gen
+		children = append(children, child1, child2)
+	}
+
+	if len(children) > numChildren {
+		children = children[:numChildren]
+	}
+
+	// При crowding повторы устраняются после него: выжившие родители могут совпасть с элитой или друг с другом
+	crowding := div.GetNiching() == proto.NichingMethod_NICHING_CROWDING
+	if div.GetEliminateDuplicates() && !crowding {
+		eliminateDuplicates(children, elite, isl.constraints, isl.rng)
+	}
+	if err := e.evaluate(ctx, children); err != nil {
+		return err
+	}
+	if crowding {
+		crowd(parents, children)
+		if div.GetEliminateDuplicates() {
+			changed := eliminateDuplicates(children, elite, isl.constraints, isl.rng)
+			reevaluate := make([]*Individual, len(changed))
+			for k, i := range changed {
+				children[i] = &Individual{TerminalMask: children[i].TerminalMask, Generation: gen}
+				reevaluate[k] = children[i]
+			}
+			if err := e.evaluate(ctx, reevaluate); err != nil {
+				return err
+			}
+		}
+	}
+	pop.Individuals = append(elite, children...)
+
+	return isl.immigrate(ctx, div, e, gen)
+}
+
+// stepIslands выполняет одно поколение на всех островах одновременно.
+func stepIslands(ctx context.Context, islands []*island, settings *proto.GASettings, e *evaluator, gen int) error {
+	if len(islands) == 1 {
+		return islands[0].step(ctx, settings, e, gen)
+	}
+	return logic.ForEach(ctx, len(islands), len(islands), func(i int) error {
+		return islands[i].step(ctx, settings, e, gen)
+	})
+}
+
+// migrate отправляет migrant_count лучших особей каждого острова соседям по топологии.
+// Мигранты заменяют худших особей острова-получателя; сами особи после оценки не изменяются,
+// поэтому острова могут разделять их без копирования.
+func migrate(islands []*island, settings *proto.IslandSettings) {
+	n := len(islands)
+	emigrants := make([][]*Individual, n)
+	for i, isl := range islands {
+		emigrants[i] = logic.Elite(isl.pop.Individuals, int(settings.MigrantCount), byFitness)
+	}
+
+	for i, isl := range islands {
+		var incoming []*Individual
+		switch settings.Topology {
+		case proto.MigrationTopology_TOPOLOGY_RING:
+			incoming = emigrants[(i-1+n)%n]
+		case proto.MigrationTopology_TOPOLOGY_FULLY_CONNECTED:
+			for j := range islands {
+				if j != i {
+					incoming = append(incoming, emigrants[j]...)
+				}
+			}
+		default:
+			panic("unsupported migration topology")
+		}
+		isl.receive(incoming)
+	}
+}
+
+func (isl *island) receive(migrants []*Individual) {
+	pop := isl.pop
+	pop.SortByFitness()
+	// Лучшая особь острова остаётся на месте
+	count := min(len(migrants), len(pop.Individuals)-1)
+	for k := 0; k < count; k++ {
+		pop.Individuals[len(pop.Individuals)-1-k] = migrants[k]
+	}
+}
+
+// bestOf возвращает лучшую особь среди всех островов.
+func bestOf(islands []*island) *Individual {
+	best := islands[0].pop.GetBest()
+	for _, isl := range islands[1:] {
+		if candidate := isl.pop.GetBest(); candidate.Fitness < best.Fitness {
+			best = candidate
+		}
+	}
+	return best
+}
+
+// individualsOf возвращает особей всех островов.
+func individualsOf(islands []*island) []*Individual {
+	if len(islands) == 1 {
+		return islands[0].pop.Individuals
+	}
+	all := make([]*Individual, 0, len(islands)*len(islands[0].pop.Individuals))
+	for _, isl := range islands {
+		all = append(all, isl.pop.Individuals...)
+	}
+	return all
+}
@@ -0,0 +1,104 @@
+package ga_level1
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"noytech-ga-optimizer/api/proto"
+)
+
+// testIslands создаёт острова по size особей; fitness особи — 100*номер острова + место в популяции,
+// так что по fitness видно, с какого острова пришла особь.
+func testIslands(count, size int) []*island {
+	islands := make([]*island, count)
+	for i := range islands {
+		pop := &Population{Individuals: make([]*Individual, size)}
+		for k := range pop.Individuals {
+			pop.Individuals[k] = &Individual{Fitness: float64(100*i + k)}
+		}
+		islands[i] = &island{pop: pop}
+	}
+	return islands
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name     string
+		islands  int
+		migrants int32
+		topology proto.MigrationTopology
+		// from(i) — сколько мигрантов остров i получает от каждого острова
+		from func(i, n int) map[int]int
+	}{
+		{"ring sends to the next island", 3, 2, proto.MigrationTopology_TOPOLOGY_RING,
+			func(i, n int) map[int]int { return map[int]int{(i - 1 + n) % n: 2} }},
+		{"fully connected sends to every other island", 3, 2, proto.MigrationTopology_TOPOLOGY_FULLY_CONNECTED,
+			func(i, n int) map[int]int {
+				from := make(map[int]int)
+				for j := range n {
+					if j != i {
+						from[j] = 2
+					}
+				}
+				return from
+			}},
+		{"fully connected keeps the island best", 4, 2, proto.MigrationTopology_TOPOLOGY_FULLY_CONNECTED,
+			func(i, n int) map[int]int {
+				// 6 мигрантов на 4 свободных места: по два от первых соседей по номеру
+				from := make(map[int]int)
+				for j := range n {
+					if j != i && len(from) < 2 {
+						from[j] = 2
+					}
+				}
+				return from
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const size = 5
+			islands := testIslands(tt.islands, size)
+			migrate(islands, &proto.IslandSettings{MigrantCount: tt.migrants, Topology: tt.topology})
+
+			for i, isl := range islands {
+				if len(isl.pop.Individuals) != size {
+					t.Fatalf("island %d has %d individuals, want %d", i, len(isl.pop.Individuals), size)
+				}
+				if best := isl.pop.Individuals[0].Fitness; best != float64(100*i) {
+					t.Errorf("island %d best = %v, want its own best %d", i, best, 100*i)
+				}
+				got := make(map[int]int)
+				for _, ind := range isl.pop.Individuals {
+					origin := int(ind.Fitness) / 100
+					if origin == i {
+						continue
+					}
+					got[origin]++
+					// Отправляются только лучшие особи острова
+					if rank := int(ind.Fitness) % 100; rank >= int(tt.migrants) {
+						t.Errorf("island %d received individual %v ranked %d on its island", i, ind.Fitness, rank)
+					}
+				}
+				if want := tt.from(i, tt.islands); !maps.Equal(got, want) {
+					t.Errorf("island %d received migrants from %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReceiveReplacesWorst(t *testing.T) {
+	isl := testIslands(1, 4)[0]
+	migrants := []*Individual{{Fitness: 50}, {Fitness: 60}}
+	isl.receive(migrants)
+
+	var got []float64
+	for _, ind := range isl.pop.Individuals {
+		got = append(got, ind.Fitness)
+	}
+	want := []float64{0, 1, 60, 50}
+	if !slices.Equal(got, want) {
+		t.Errorf("population fitness = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,172 @@
+package ga_level1
+
+import (
+	"context"
+	"time"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
+)
+
+// LocalSearchStats — вклад локального поиска за прогон.
+type LocalSearchStats struct {
+	Evaluations int64   // Оценок fitness соседних решений
+	Improved    int     // Сколько раз особь была улучшена
+	Improvement float64 // Суммарное снижение fitness улучшенных особей, включая не дошедших до конца прогона
+
+	// Снижение fitness итогового лучшего решения локальным поиском: до локального поиска его fitness
+	// был Best.Fitness + BestImprovement
+	BestImprovement float64
+}
+
+// localSearch улучшает особей ходами add/drop/swap по маске терминалов. Бюджет оценок и времени
+// общий на весь прогон. Соседи перебираются в фиксированном порядке и без ГСЧ, поэтому при бюджете
+// только по оценкам результат воспроизводим.
+type localSearch struct {
+	settings    *proto.LocalSearchSettings
+	constraints Constraints
+	evaluator   *evaluator
+	deadline    time.Time
+	generation  int // Поколение, которым помечаются улучшенные особи
+	stats       LocalSearchStats
+}
+
+func newLocalSearch(settings *proto.LocalSearchSettings, constraints Constraints, e *evaluator) *localSearch {
+	ls := &localSearch{
+		settings:    settings,
+		constraints: constraints,
+		evaluator:   e,
+	}
+	if settings.MaxDurationMs > 0 {
+		ls.deadline = time.Now().Add(time.Duration(settings.MaxDurationMs) * time.Millisecond)
+	}
+	return ls
+}
+
+func (ls *localSearch) exhausted() bool {
+	if ls.settings.MaxEvaluations > 0 && ls.stats.Evaluations >= int64(ls.settings.MaxEvaluations) {
+		return true
+	}
+	return !ls.deadline.IsZero() && time.Now().After(ls.deadline)
+}
+
+// improve спускается от ind по улучшающим ходам, пока они есть и бюджет не исчерпан.
+// Возвращает улучшенную копию или саму ind, если улучшить не удалось.
+func (ls *localSearch) improve(ctx context.Context, ind *Individual) (*Individual, error) {
+	current := ind
+	for !ls.exhausted() {
+		next, err := ls.move(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			break
+		}
+		current = next
+	}
+
+	if current != ind {
+		current.Generation = ls.generation
+		ls.stats.Improved++
+		ls.stats.Improvement += ind.Fitness - current.Fitness
+		current.LocalSearchGain = ind.LocalSearchGain + ind.Fitness - current.Fitness
+	}
+	return current, nil
+}
+
+// move оценивает соседей current пачками по числу воркеров и возвращает улучшающий ход:
+// первый найденный или лучший в зависимости от стратегии. nil — улучшающих ходов нет.
+func (ls *localSearch) move(ctx context.Context, current *Individual) (*Individual, error) {
+	neighbors := ls.neighborhood(current.TerminalMask)
+	batchSize := max(ls.evaluator.workers, 1)
+
+	var best *Individual
+	for start := 0; start < len(neighbors) && !ls.exhausted(); start += batchSize {
+		end := min(start+batchSize, len(neighbors))
+		if ls.settings.MaxEvaluations > 0 {
+			end = min(end, start+int(int64(ls.settings.MaxEvaluations)-ls.stats.Evaluations))
+		}
+
+		batch := make([]*Individual, end-start)
+		for i := range batch {
+			batch[i] = &Individual{TerminalMask: neighbors[start+i]}
+		}
+		if err := ls.evaluator.evaluate(ctx, batch); err != nil {
+			return nil, err
+		}
+		ls.stats.Evaluations += int64(len(batch))
+
+		for _, n := range batch {
+			if n.Fitness < current.Fitness && (best == nil || n.Fitness < best.Fitness) {
+				best = n
+			}
+		}
+		if best != nil && ls.settings.Strategy == proto.LocalSearchStrategy_LOCAL_SEARCH_FIRST_IMPROVEMENT {
+			return best, nil
+		}
+	}
+	return best, nil
+}
+
+// neighborhood — маски, отличающиеся от mask открытием, закрытием или заменой одного терминала
+// и удовлетворяющие ограничениям.
+func (ls *localSearch) neighborhood(mask []bool) [][]bool {
+	var open, closed []int
+	for i, on := range mask {
+		if on {
+			open = append(open, i)
+		} else {
+			closed = append(closed, i)
+		}
+	}
+
+	neighbors := make([][]bool, 0, len(mask)+len(open)*len(closed))
+	add := func(flip ...int) {
+		n := append([]bool(nil), mask...)
+		for _, i := range flip {
+			n[i] = !n[i]
+		}
+		if ls.constraints.Satisfied(n) {
+			neighbors = append(neighbors, n)
+		}
+	}
+
+	for _, i := range closed {
+		add(i)
+	}
+	for _, i := range open {
+		add(i)
+	}
+	for _, i := range open {
+		for _, j := range closed {
+			add(i, j)
+		}
+	}
+	return neighbors
+}
+
+// improveElite применяет локальный поиск к count лучшим особям каждого острова
+// и заменяет их в популяции улучшенными.
+func (ls *localSearch) improveElite(ctx context.Context, islands []*island, count int) error {
+	for _, isl := range islands {
+		for _, ind := range logic.Elite(isl.pop.Individuals, count, byFitness) {
+			if ls.exhausted() {
+				return nil
+			}
+			improved, err := ls.improve(ctx, ind)
+			if err != nil {
+				return err
+			}
+			if improved == ind {
+				continue
+			}
+			for i, p := range isl.pop.Individuals {
+				if p == ind {
+					isl.pop.Individuals[i] = improved
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
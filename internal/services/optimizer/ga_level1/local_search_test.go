@@ -0,0 +1,120 @@
+package ga_level1
+
+import (
+	"context"
+	"testing"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+)
+
+func TestLocalSearchNeighborhood(t *testing.T) {
+	terminals := []models.Terminal{{City: "A"}, {City: "B"}, {City: "C"}, {City: "D"}, {City: "E"}}
+	mask := []bool{true, true, false, false, false}
+
+	tests := []struct {
+		name        string
+		constraints Constraints
+		want        int
+	}{
+		// 3 открытия, 2 закрытия и 2×3 замены
+		{"no constraints", Constraints{}, 11},
+		// Только закрыть B или заменить B на закрытый терминал
+		{"pinned and bounded", NewConstraints(terminals, []string{"A"}, nil, 0, 2), 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := &localSearch{constraints: tt.constraints}
+			neighbors := ls.neighborhood(mask)
+			if len(neighbors) != tt.want {
+				t.Errorf("got %d neighbors, want %d: %v", len(neighbors), tt.want, neighbors)
+			}
+			seen := make(map[string]bool)
+			for _, n := range neighbors {
+				diff := 0
+				for i := range n {
+					if n[i] != mask[i] {
+						diff++
+					}
+				}
+				if diff < 1 || diff > 2 {
+					t.Errorf("neighbor %v differs from %v in %d terminals", n, mask, diff)
+				}
+				if !tt.constraints.Satisfied(n) {
+					t.Errorf("neighbor %v violates constraints", n)
+				}
+				if key := maskKey(n); seen[key] {
+					t.Errorf("neighbor %v repeated", n)
+				} else {
+					seen[key] = true
+				}
+			}
+		})
+	}
+}
+
+func TestLocalSearchReachesLocalOptimum(t *testing.T) {
+	d := newTestDay()
+	for _, strategy := range []proto.LocalSearchStrategy{
+		proto.LocalSearchStrategy_LOCAL_SEARCH_FIRST_IMPROVEMENT,
+		proto.LocalSearchStrategy_LOCAL_SEARCH_BEST_IMPROVEMENT,
+	} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			e := d.evaluator(4, nil)
+			start := &Individual{TerminalMask: []bool{true, true, true, true, true, true, true, true}}
+			if err := e.evaluate(context.Background(), []*Individual{start}); err != nil {
+				t.Fatal(err)
+			}
+
+			ls := newLocalSearch(&proto.LocalSearchSettings{Strategy: strategy}, Constraints{}, e)
+			improved, err := ls.improve(context.Background(), start)
+			if err != nil {
+				t.Fatalf("improve: %v", err)
+			}
+			if improved.Fitness >= start.Fitness {
+				t.Fatalf("fitness %v not improved from %v", improved.Fitness, start.Fitness)
+			}
+			if gain := start.Fitness - improved.Fitness; improved.LocalSearchGain != gain || ls.stats.Improvement != gain {
+				t.Errorf("gain = %v, stats improvement = %v, want %v", improved.LocalSearchGain, ls.stats.Improvement, gain)
+			}
+
+			var neighbors []*Individual
+			for _, m := range ls.neighborhood(improved.TerminalMask) {
+				neighbors = append(neighbors, &Individual{TerminalMask: m})
+			}
+			if err := e.evaluate(context.Background(), neighbors); err != nil {
+				t.Fatal(err)
+			}
+			for _, n := range neighbors {
+				if n.Fitness < improved.Fitness {
+					t.Errorf("neighbor %v (%v) is better than local optimum %v (%v)",
+						n.TerminalMask, n.Fitness, improved.TerminalMask, improved.Fitness)
+				}
+			}
+		})
+	}
+}
+
+func TestLocalSearchEvaluationBudget(t *testing.T) {
+	d := newTestDay()
+	e := d.evaluator(4, nil)
+	start := &Individual{TerminalMask: []bool{true, true, true, true, true, true, true, true}}
+	if err := e.evaluate(context.Background(), []*Individual{start}); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := &proto.LocalSearchSettings{
+		Strategy:       proto.LocalSearchStrategy_LOCAL_SEARCH_BEST_IMPROVEMENT,
+		MaxEvaluations: 6,
+	}
+	ls := newLocalSearch(settings, Constraints{}, e)
+	if _, err := ls.improve(context.Background(), start); err != nil {
+		t.Fatalf("improve: %v", err)
+	}
+	if ls.stats.Evaluations != 6 {
+		t.Errorf("spent %d evaluations, budget 6", ls.stats.Evaluations)
+	}
+	if !ls.exhausted() {
+		t.Error("budget is not reported as exhausted")
+	}
+}
@@ -5,25 +5,32 @@ import (
 	"noytech-ga-optimizer/api/proto"
 )
 
-func Mutate(ind *Individual, prob float64, method proto.MutationType) {
-	if rand.Float64() > prob {
+// Mutate применяет оператор мутации к маске терминалов; для побитовой мутации rate — вероятность на каждый ген.
+func Mutate(ind *Individual, rate float64, method proto.MutationType, rng *rand.Rand) {
+	if method == proto.MutationType_MUTATION_BIT_FLIP {
+		bitFlip(ind.TerminalMask, rate, rng)
+		return
+	}
+	if rng.Float64() > rate {
 		return
 	}
 	switch method {
 	case proto.MutationType_MUTATION_INVERSION:
-		inversion(ind.TerminalMask)
+		inversion(ind.TerminalMask, rng)
 	case proto.MutationType_MUTATION_SWAP:
-		swap(ind.TerminalMask)
+		swap(ind.TerminalMask, rng)
+	case proto.MutationType_MUTATION_ADD_DROP_SWAP:
+		addDropSwap(ind.TerminalMask, rng)
 	default:
 		panic("unsupported mutation type")
 	}
 }
 
-func inversion(mask []bool) {
+func inversion(mask []bool, rng *rand.Rand) {
 	if len(mask) < 2 {
 		return
 	}
-	i, j := rand.Intn(len(mask)), rand.Intn(len(mask))
+	i, j := rng.Intn(len(mask)), rng.Intn(len(mask))
 	if i > j {
 		i, j = j, i
 	}
@@ -32,10 +39,60 @@ func inversion(mask []bool) {
 	}
 }
 
-func swap(mask []bool) {
+func swap(mask []bool, rng *rand.Rand) {
 	if len(mask) < 2 {
 		return
 	}
-	i, j := rand.Intn(len(mask)), rand.Intn(len(mask))
+	i, j := rng.Intn(len(mask)), rng.Intn(len(mask))
 	mask[i], mask[j] = mask[j], mask[i]
 }
+
+func bitFlip(mask []bool, rate float64, rng *rand.Rand) {
+	for i := range mask {
+		if rng.Float64() < rate {
+			mask[i] = !mask[i]
+		}
+	}
+}
+
+// addDropSwap открывает, закрывает (кроме последнего открытого) или заменяет один терминал.
+func addDropSwap(mask []bool, rng *rand.Rand) {
+	open, closed := make([]int, 0, len(mask)), make([]int, 0, len(mask))
+	for i, active := range mask {
+		if active {
+			open = append(open, i)
+		} else {
+			closed = append(closed, i)
+		}
+	}
+
+	type move int
+	const (
+		add move = iota
+		drop
+		replace
+	)
+	moves := make([]move, 0, 3)
+	if len(closed) > 0 {
+		moves = append(moves, add)
+	}
+	if len(open) > 1 {
+		moves = append(moves, drop)
+	}
+	if len(open) > 0 && len(closed) > 0 {
+		moves = append(moves, replace)
+	}
+	if len(moves) == 0 {
+		return
+	}
+
+	switch moves[rng.Intn(len(moves))] {
+	case add:
+		mask[closed[rng.Intn(len(closed))]] = true
+	case drop:
+		mask[open[rng.Intn(len(open))]] = false
+	case replace:
+		mask[open[rng.Intn(len(open))]] = false
+		mask[closed[rng.Intn(len(closed))]] = true
+	}
+}
@@ -0,0 +1,349 @@
+package ga_level1
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
+)
+
+// infeasibleObjective — значение любой цели для особи без открытых терминалов: такая особь
+// доминируется любым допустимым решением и не попадает во фронт.
+const infeasibleObjective = 1e12
+
+// RunNSGA2 — многокритериальный ГА 1-го уровня (NSGA-II) по целям settings.Objectives; selection_type, elite_count и stop_conditions не используются.
+// Result.Front — недоминируемые решения последнего поколения без повторов, по возрастанию первой цели.
+func RunNSGA2(
+	ctx context.Context,
+	settings *proto.GASettings,
+	rng *rand.Rand,
+	workers int,
+	cache *FitnessCache,
+	terminals []models.Terminal,
+	constraints Constraints,
+	seeds [][]bool,
+	shipments []models.Shipment,
+	interCityRates []models.InterCityRate,
+	intraCityRates []models.IntraCityRate,
+	distances map[string]map[string]int,
+	fleet logic.Fleet,
+	onProgress ProgressFunc,
+) (*Result, error) {
+	e := &evaluator{
+		workers:        workers,
+		cache:          cache,
+		terminals:      terminals,
+		shipments:      shipments,
+		interCityRates: interCityRates,
+		intraCityRates: intraCityRates,
+		distances:      distances,
+		fleet:          fleet,
+	}
+
+	size := int(settings.NumIndividuals)
+	in, err := newInitializer(ctx, settings.Init, e, constraints, seeds)
+	if err != nil {
+		return nil, err
+	}
+	pop := in.population(size, terminals, constraints, rng)
+	if err := e.evaluate(ctx, pop.Individuals); err != nil {
+		return nil, err
+	}
+	assignObjectives(pop.Individuals, settings.Objectives)
+	pop.Individuals = selectSurvivors(pop.Individuals, size)
+
+	runCtx, cancel := logic.WithBudget(ctx, settings.MaxDurationMs)
+	defer cancel()
+
+	result := &Result{}
+	front := frontKeys(pop.Individuals)
+	noImprove := 0
+	mutationRate, crossoverRate := logic.Rates(settings)
+
+	// Поколение 0 — начальная популяция
+	for gen := 0; ; gen++ {
+		if gen > 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			children := make([]*Individual, 0, size+1)
+			for len(children) < size {
+				p1 := crowdedTournament(pop.Individuals, rng)
+				p2 := crowdedTournament(pop.Individuals, rng)
+				var child1, child2 *Individual
+				if crossoverRate >= 1 || rng.Float64() < crossoverRate {
+					child1, child2 = Crossover(p1, p2, settings.CrossoverType, rng)
+				} else {
+					child1, child2 = p1.Clone(), p2.Clone()
+				}
+				Mutate(child1, mutationRate, settings.MutationType, rng)
+				Mutate(child2, mutationRate, settings.MutationType, rng)
+				constraints.Repair(child1.TerminalMask, rng)
+				constraints.Repair(child2.TerminalMask, rng)
+				child1.Generation, child2.Generation = gen, gen
+				children = append(children, child1, child2)
+			}
+			children = children[:size]
+
+			if err := e.evaluate(runCtx, children); err != nil {
+				if !logic.BudgetExceeded(ctx, runCtx) {
+					return nil, err
+				}
+				result.StopReason, result.StopGeneration = proto.StopReason_STOP_TIME_LIMIT, gen
+				break
+			}
+			assignObjectives(children, settings.Objectives)
+			pop.Individuals = selectSurvivors(append(pop.Individuals, children...), size)
+
+			if currentFront := frontKeys(pop.Individuals); !sameKeys(currentFront, front) {
+				front = currentFront
+				noImprove = 0
+			} else {
+				noImprove++
+			}
+		}
+
+		if onProgress != nil {
+			onProgress(newProgress(pop.Individuals, pop.GetBest(), gen, int(settings.NumGenerations), noImprove))
+		}
+
+		reason := proto.StopReason_STOP_REASON_UNSPECIFIED
+		switch {
+		case noImprove >= int(settings.StoppingCriterion):
+			reason = proto.StopReason_STOP_NO_IMPROVEMENT
+		case runCtx.Err() != nil:
+			reason = proto.StopReason_STOP_TIME_LIMIT
+		case gen >= int(settings.NumGenerations):
+			reason = proto.StopReason_STOP_NUM_GENERATIONS
+		}
+		if reason != proto.StopReason_STOP_REASON_UNSPECIFIED {
+			result.StopReason, result.StopGeneration = reason, gen
+			break
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result.TimeLimited = result.StopReason == proto.StopReason_STOP_TIME_LIMIT
+	result.Evaluations = e.evaluations.Load()
+	result.Front = paretoFront(pop.Individuals)
+	result.Best = result.Front[0]
+	return result, nil
+}
+
+// ObjectiveValue возвращает значение цели для оценённой особи (все цели минимизируются).
+func ObjectiveValue(ind *Individual, objective proto.Objective) float64 {
+	if len(ind.ActiveTerminals) == 0 {
+		return infeasibleObjective
+	}
+	switch objective {
+	case proto.Objective_OBJECTIVE_TOTAL_COST:
+		return ind.Fitness
+	case proto.Objective_OBJECTIVE_ACTIVE_TERMINALS:
+		return float64(len(ind.ActiveTerminals))
+	case proto.Objective_OBJECTIVE_AVG_DISTANCE:
+		return ind.AvgDistanceKm
+	default:
+		panic("unsupported objective")
+	}
+}
+
+func assignObjectives(individuals []*Individual, objectives []proto.Objective) {
+	for _, ind := range individuals {
+		ind.Objectives = make([]float64, len(objectives))
+		for k, o := range objectives {
+			ind.Objectives[k] = ObjectiveValue(ind, o)
+		}
+	}
+}
+
+// dominates сообщает, что a не хуже b по всем целям и лучше хотя бы по одной.
+func dominates(a, b *Individual) bool {
+	better := false
+	for k := range a.Objectives {
+		if a.Objectives[k] > b.Objectives[k] {
+			return false
+		}
+		if a.Objectives[k] < b.Objectives[k] {
+			better = true
+		}
+	}
+	return better
+}
+
+// ParetoRanks возвращает номер фронта Парето (с 1) каждого вектора минимизируемых целей.
+func ParetoRanks(objectives [][]float64) []int {
+	individuals := make([]*Individual, len(objectives))
+	for i, o := range objectives {
+		individuals[i] = &Individual{Objectives: o}
+	}
+	nonDominatedSort(individuals)
+
+	ranks := make([]int, len(individuals))
+	for i, ind := range individuals {
+		ranks[i] = ind.Rank
+	}
+	return ranks
+}
+
+// nonDominatedSort разбивает особей на фронты Парето и проставляет Rank (с 1).
+func nonDominatedSort(individuals []*Individual) [][]*Individual {
+	n := len(individuals)
+	dominatedBy := make([]int, n)  // Сколько особей доминируют i-ю
+	dominating := make([][]int, n) // Кого доминирует i-я
+
+	fronts := [][]*Individual{{}}
+	current := make([]int, 0)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			switch {
+			case dominates(individuals[i], individuals[j]):
+				dominating[i] = append(dominating[i], j)
+				dominatedBy[j]++
+			case dominates(individuals[j], individuals[i]):
+				dominating[j] = append(dominating[j], i)
+				dominatedBy[i]++
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		if dominatedBy[i] == 0 {
+			individuals[i].Rank = 1
+			current = append(current, i)
+			fronts[0] = append(fronts[0], individuals[i])
+		}
+	}
+
+	for rank := 2; len(current) > 0; rank++ {
+		next := make([]int, 0)
+		front := make([]*Individual, 0)
+		for _, i := range current {
+			for _, j := range dominating[i] {
+				dominatedBy[j]--
+				if dominatedBy[j] == 0 {
+					individuals[j].Rank = rank
+					next = append(next, j)
+					front = append(front, individuals[j])
+				}
+			}
+		}
+		if len(front) > 0 {
+			fronts = append(fronts, front)
+		}
+		current = next
+	}
+	return fronts
+}
+
+// assignCrowding считает crowding distance особей одного фронта: крайние по любой цели получают
+// бесконечность, остальные — сумму нормированных расстояний до соседей.
+func assignCrowding(front []*Individual) {
+	for _, ind := range front {
+		ind.crowding = 0
+	}
+	if len(front) == 0 {
+		return
+	}
+
+	sorted := make([]*Individual, len(front))
+	copy(sorted, front)
+	for k := range front[0].Objectives {
+		sort.SliceStable(sorted, func(a, b int) bool {
+			return sorted[a].Objectives[k] < sorted[b].Objectives[k]
+		})
+		lo, hi := sorted[0].Objectives[k], sorted[len(sorted)-1].Objectives[k]
+		sorted[0].crowding = math.Inf(1)
+		sorted[len(sorted)-1].crowding = math.Inf(1)
+		if hi == lo {
+			continue
+		}
+		for i := 1; i < len(sorted)-1; i++ {
+			sorted[i].crowding += (sorted[i+1].Objectives[k] - sorted[i-1].Objectives[k]) / (hi - lo)
+		}
+	}
+}
+
+// selectSurvivors оставляет size лучших особей: фронты целиком по возрастанию ранга,
+// последний помещающийся частично — по убыванию crowding distance.
+func selectSurvivors(individuals []*Individual, size int) []*Individual {
+	survivors := make([]*Individual, 0, size)
+	for _, front := range nonDominatedSort(individuals) {
+		assignCrowding(front)
+		if len(survivors)+len(front) <= size {
+			survivors = append(survivors, front...)
+			continue
+		}
+		sort.SliceStable(front, func(a, b int) bool {
+			return front[a].crowding > front[b].crowding
+		})
+		survivors = append(survivors, front[:size-len(survivors)]...)
+		break
+	}
+	return survivors
+}
+
+// crowdedTournament — бинарный турнир: меньший ранг, при равенстве — больший crowding distance.
+func crowdedTournament(pop []*Individual, rng *rand.Rand) *Individual {
+	a := pop[rng.Intn(len(pop))]
+	b := pop[rng.Intn(len(pop))]
+	if b.Rank < a.Rank || (b.Rank == a.Rank && b.crowding > a.crowding) {
+		return b
+	}
+	return a
+}
+
+// paretoFront возвращает особей первого фронта без повторяющихся масок, по возрастанию целей.
+func paretoFront(individuals []*Individual) []*Individual {
+	seen := make(map[string]bool)
+	front := make([]*Individual, 0)
+	for _, ind := range individuals {
+		if ind.Rank != 1 {
+			continue
+		}
+		key := maskKey(ind.TerminalMask)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		front = append(front, ind)
+	}
+
+	sort.SliceStable(front, func(a, b int) bool {
+		for k := range front[a].Objectives {
+			if front[a].Objectives[k] != front[b].Objectives[k] {
+				return front[a].Objectives[k] < front[b].Objectives[k]
+			}
+		}
+		return false
+	})
+	return front
+}
+
+// frontKeys — маски первого фронта, по которым отслеживается его изменение между поколениями.
+func frontKeys(individuals []*Individual) map[string]bool {
+	keys := make(map[string]bool)
+	for _, ind := range individuals {
+		if ind.Rank == 1 {
+			keys[maskKey(ind.TerminalMask)] = true
+		}
+	}
+	return keys
+}
+
+func sameKeys(a, b map[string]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if !b[k] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package ga_level1
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"noytech-ga-optimizer/api/proto"
+)
+
+func TestParetoRanks(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectives [][]float64
+		want       []int
+	}{
+		{"single", [][]float64{{1, 1}}, []int{1}},
+		{"chain", [][]float64{{3, 3}, {1, 1}, {2, 2}}, []int{3, 1, 2}},
+		{"trade-off front", [][]float64{{1, 5}, {2, 2}, {5, 1}}, []int{1, 1, 1}},
+		{"layers", [][]float64{{1, 5}, {2, 2}, {5, 1}, {3, 3}, {4, 4}, {6, 6}, {2, 6}}, []int{1, 1, 1, 2, 3, 4, 2}},
+		{"equal vectors do not dominate each other", [][]float64{{2, 2}, {2, 2}, {3, 2}}, []int{1, 1, 2}},
+		{"three objectives", [][]float64{{1, 2, 3}, {3, 2, 1}, {2, 2, 2}, {3, 3, 3}}, []int{1, 1, 1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParetoRanks(tt.objectives)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("ParetoRanks = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNonDominatedSortFronts(t *testing.T) {
+	individuals := individualsWith([][]float64{{1, 5}, {3, 3}, {2, 2}, {4, 4}, {5, 1}})
+	fronts := nonDominatedSort(individuals)
+
+	wantSizes := []int{3, 1, 1}
+	if len(fronts) != len(wantSizes) {
+		t.Fatalf("got %d fronts, want %d", len(fronts), len(wantSizes))
+	}
+	for r, front := range fronts {
+		if len(front) != wantSizes[r] {
+			t.Errorf("front %d has %d individuals, want %d", r+1, len(front), wantSizes[r])
+		}
+		for _, ind := range front {
+			if ind.Rank != r+1 {
+				t.Errorf("individual %v in front %d has rank %d", ind.Objectives, r+1, ind.Rank)
+			}
+		}
+	}
+}
+
+func TestAssignCrowding(t *testing.T) {
+	inf := math.Inf(1)
+	tests := []struct {
+		name       string
+		objectives [][]float64
+		want       []float64
+	}{
+		{"single", [][]float64{{1, 1}}, []float64{inf}},
+		{"two", [][]float64{{0, 1}, {1, 0}}, []float64{inf, inf}},
+		{"evenly spaced", [][]float64{{0, 3}, {1, 2}, {2, 1}, {3, 0}}, []float64{inf, 4.0 / 3, 4.0 / 3, inf}},
+		{"uneven", [][]float64{{0, 4}, {1, 3}, {4, 0}}, []float64{inf, 2, inf}},
+		{"constant objective adds nothing", [][]float64{{0, 5}, {1, 5}, {4, 5}}, []float64{inf, 1, inf}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			front := individualsWith(tt.objectives)
+			assignCrowding(front)
+			for i, ind := range front {
+				if ind.crowding != tt.want[i] && math.Abs(ind.crowding-tt.want[i]) > 1e-9 {
+					t.Errorf("crowding of %v = %v, want %v", ind.Objectives, ind.crowding, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func (d testDay) runNSGA2(t *testing.T, settings *proto.GASettings, seed int64) []*Individual {
+	t.Helper()
+	result, err := RunNSGA2(context.Background(), settings, rand.New(rand.NewSource(seed)), 4, NewFitnessCache(1000), d.terminals,
+		Constraints{}, nil, d.shipments, d.interCityRates, d.intraCityRates, d.distances, d.fleet, nil)
+	if err != nil {
+		t.Fatalf("RunNSGA2: %v", err)
+	}
+	return result.Front
+}
+
+func TestRunNSGA2IsReproducible(t *testing.T) {
+	d := newTestDay()
+	settings := testSettings()
+	settings.Objectives = []proto.Objective{proto.Objective_OBJECTIVE_TOTAL_COST, proto.Objective_OBJECTIVE_ACTIVE_TERMINALS}
+
+	first := d.runNSGA2(t, settings, 42)
+	second := d.runNSGA2(t, settings, 42)
+	if len(first) == 0 {
+		t.Fatal("empty Pareto front")
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("runs with the same seed return different fronts:\n%+v\n%+v", first, second)
+	}
+	for _, a := range first {
+		for _, b := range first {
+			if dominates(a, b) {
+				t.Errorf("front solution %v dominates %v", a.Objectives, b.Objectives)
+			}
+		}
+	}
+}
+
+func individualsWith(objectives [][]float64) []*Individual {
+	individuals := make([]*Individual, len(objectives))
+	for i, o := range objectives {
+		individuals[i] = &Individual{Objectives: o}
+	}
+	return individuals
+}
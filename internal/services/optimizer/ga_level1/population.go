@@ -1,9 +1,11 @@
 package ga_level1
 
 import (
-	"math/rand"
+	"context"
 	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
 	"sort"
+	"sync/atomic"
 )
 
 type Population struct {
@@ -11,30 +13,67 @@ type Population struct {
 	AllTerminals []models.Terminal
 }
 
-func NewRandomPopulation(size int, terminals []models.Terminal) *Population {
-	pop := &Population{
-		Individuals:  make([]*Individual, size),
-		AllTerminals: terminals,
+// evaluator — данные прогона, по которым считается fitness особей.
+type evaluator struct {
+	workers        int
+	cache          *FitnessCache
+	terminals      []models.Terminal
+	shipments      []models.Shipment
+	interCityRates []models.InterCityRate
+	intraCityRates []models.IntraCityRate
+	distances      map[string]map[string]int
+	fleet          logic.Fleet
+	evaluations    atomic.Int64 // Особей, переданных в evaluate, включая попадания в кэш
+}
+
+func (e *evaluator) calculate(ind *Individual) error {
+	return CalculateFitness(ind, e.terminals, e.shipments, e.interCityRates, e.intraCityRates, e.distances, e.fleet)
+}
+
+// evaluate считает fitness особей на workers горутинах; маски из cache и повторы внутри поколения не пересчитываются.
+func (e *evaluator) evaluate(ctx context.Context, individuals []*Individual) error {
+	e.evaluations.Add(int64(len(individuals)))
+	if e.cache == nil {
+		return logic.ForEach(ctx, len(individuals), e.workers, func(i int) error {
+			return e.calculate(individuals[i])
+		})
 	}
-	for i := 0; i < size; i++ {
-		mask := make([]bool, len(terminals))
-		for j := range mask {
-			mask[j] = rand.Float32() < 0.3
+
+	// 1. Отбираем маски, которых нет в кэше, без повторов
+	keys := make([]string, 0, len(individuals))
+	pending := make([]*Individual, 0, len(individuals))
+	first := make(map[string]*Individual)
+	duplicates := make(map[*Individual][]*Individual)
+	for _, ind := range individuals {
+		key := maskKey(ind.TerminalMask)
+		if f, seen := first[key]; seen {
+			duplicates[f] = append(duplicates[f], ind)
+			continue
+		}
+		first[key] = ind
+		if e.cache.load(key, ind) {
+			continue
 		}
-		pop.Individuals[i] = &Individual{TerminalMask: mask}
+		keys = append(keys, key)
+		pending = append(pending, ind)
 	}
-	return pop
-}
 
-func (p *Population) Evaluate(
-	shipments []models.Shipment,
-	interCityRates []models.InterCityRate,
-	intraCityRates []models.IntraCityRate,
-	distances map[string]map[string]int,
-) error {
-	for _, ind := range p.Individuals {
-		if err := CalculateFitness(ind, p.AllTerminals, shipments, interCityRates, intraCityRates, distances); err != nil {
-			return err
+	// 2. Считаем их параллельно
+	err := logic.ForEach(ctx, len(pending), e.workers, func(i int) error {
+		return e.calculate(pending[i])
+	})
+	if err != nil {
+		return err
+	}
+
+	// 3. Сохраняем в кэш и раздаём повторам. Повторы копируются из первой особи с той же маской, а не из кэша:
+	// параллельная оценка с тем же кэшем могла уже вытеснить маску
+	for i, ind := range pending {
+		e.cache.store(keys[i], ind)
+	}
+	for f, dups := range duplicates {
+		for _, dup := range dups {
+			dup.copyEvaluation(f)
 		}
 	}
 	return nil
@@ -0,0 +1,33 @@
+package ga_level1
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func cloneAll(individuals []*Individual) []*Individual {
+	clones := make([]*Individual, len(individuals))
+	for i, ind := range individuals {
+		clones[i] = ind.Clone()
+	}
+	return clones
+}
+
+func TestEvaluateParallelMatchesSerial(t *testing.T) {
+	d := newTestDay()
+	in := &initializer{probs: []float64{0.3, 0.3, 0.3, 0.3, 0.3, 0.3, 0.3, 0.3}}
+	pop := in.population(24, d.terminals, Constraints{}, rand.New(rand.NewSource(7)))
+
+	serial, parallel := cloneAll(pop.Individuals), cloneAll(pop.Individuals)
+	if err := d.evaluator(1, nil).evaluate(context.Background(), serial); err != nil {
+		t.Fatalf("serial evaluate: %v", err)
+	}
+	if err := d.evaluator(8, nil).evaluate(context.Background(), parallel); err != nil {
+		t.Fatalf("parallel evaluate: %v", err)
+	}
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Error("parallel evaluation differs from serial")
+	}
+}
@@ -0,0 +1,51 @@
+package ga_level1
+
+import "sort"
+
+// Progress — состояние ГА после очередного поколения.
+type Progress struct {
+	Generation      int
+	NumGenerations  int
+	BestFitness     float64  // Лучшее значение за весь прогон
+	MeanFitness     float64  // Среднее по текущей популяции
+	MedianFitness   float64  // Медиана по текущей популяции
+	WorstFitness    float64  // Худшее в текущей популяции
+	Diversity       float64  // Средняя доля различающихся генов у пары особей текущей популяции
+	DistinctGenomes int      // Различных масок в текущей популяции
+	NoImprovement   int      // Поколений подряд без улучшения лучшего решения
+	ActiveTerminals []string // Активные терминалы лучшего решения
+}
+
+// ProgressFunc вызывается после каждого поколения. Может быть nil.
+type ProgressFunc func(Progress)
+
+func newProgress(individuals []*Individual, best *Individual, gen, numGenerations, noImprove int) Progress {
+	fitness := make([]float64, len(individuals))
+	distinct := make(map[string]bool, len(individuals))
+	sum := 0.0
+	for i, ind := range individuals {
+		fitness[i] = ind.Fitness
+		distinct[maskKey(ind.TerminalMask)] = true
+		sum += ind.Fitness
+	}
+	sort.Float64s(fitness)
+
+	n := len(fitness)
+	median := fitness[n/2]
+	if n%2 == 0 {
+		median = (fitness[n/2-1] + fitness[n/2]) / 2
+	}
+
+	return Progress{
+		Generation:      gen,
+		NumGenerations:  numGenerations,
+		BestFitness:     best.Fitness,
+		MeanFitness:     sum / float64(n),
+		MedianFitness:   median,
+		WorstFitness:    fitness[n-1],
+		Diversity:       diversity(individuals),
+		DistinctGenomes: len(distinct),
+		NoImprovement:   noImprove,
+		ActiveTerminals: append([]string(nil), best.ActiveTerminals...),
+	}
+}
@@ -3,53 +3,63 @@ package ga_level1
 import (
 	"math/rand"
 	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
 	"sort"
 )
 
-func SelectParents(pop []*Individual, count int, method proto.SelectionType) []*Individual {
+// SelectParents выбирает count родителей по costs — минимизируемым значениям особей для селекции
+// (например, с учётом fitness sharing); nil — по Fitness.
+func SelectParents(pop []*Individual, costs []float64, count int, method proto.SelectionType, settings *proto.GASettings, rng *rand.Rand) []*Individual {
+	if costs == nil {
+		costs = make([]float64, len(pop))
+		for i, p := range pop {
+			costs[i] = p.Fitness
+		}
+	}
+
 	switch method {
 	case proto.SelectionType_SELECTION_TOURNAMENT:
-		return tournamentSelection(pop, count)
+		return tournamentSelection(pop, costs, count, logic.TournamentSize(settings), rng)
 	case proto.SelectionType_SELECTION_ROULETTE:
-		return rouletteWheelSelection(pop, count)
+		return rouletteWheelSelection(pop, costs, count, settings.Scaling, rng)
 	case proto.SelectionType_SELECTION_RANK:
-		return rankSelection(pop, count)
+		return rankSelection(pop, costs, count, settings.Scaling, rng)
 	default:
 		panic("unsupported selection type")
 	}
 }
 
-func tournamentSelection(pop []*Individual, count int) []*Individual {
+func tournamentSelection(pop []*Individual, costs []float64, count, tSize int, rng *rand.Rand) []*Individual {
 	parents := make([]*Individual, count)
-	tSize := 3
 	for i := 0; i < count; i++ {
-		tour := make([]*Individual, tSize)
+		tour := make([]int, tSize)
 		for j := 0; j < tSize; j++ {
-			tour[j] = pop[rand.Intn(len(pop))]
+			tour[j] = rng.Intn(len(pop))
 		}
 		best := tour[0]
-		for _, p := range tour[1:] {
-			if p.Fitness < best.Fitness {
-				best = p
+		for _, k := range tour[1:] {
+			if costs[k] < costs[best] {
+				best = k
 			}
 		}
-		parents[i] = best
+		parents[i] = pop[best]
 	}
 	return parents
 }
 
-func rouletteWheelSelection(pop []*Individual, count int) []*Individual {
+func rouletteWheelSelection(pop []*Individual, costs []float64, count int, scaling *proto.FitnessScaling, rng *rand.Rand) []*Individual {
 	parents := make([]*Individual, count)
+	weights := logic.RouletteWeights(costs, scaling)
 	total := 0.0
-	for _, p := range pop {
-		total += 1.0 / (1.0 + p.Fitness)
+	for _, w := range weights {
+		total += w
 	}
 	for i := 0; i < count; i++ {
-		r := rand.Float64() * total
+		r := rng.Float64() * total
 		cum := 0.0
-		for _, p := range pop {
-			cum += 1.0 / (1.0 + p.Fitness)
-			if cum >= r {
+		for k, p := range pop {
+			cum += weights[k]
+			if weights[k] > 0 && cum >= r {
 				parents[i] = p
 				break
 			}
@@ -58,22 +68,27 @@ func rouletteWheelSelection(pop []*Individual, count int) []*Individual {
 	return parents
 }
 
-func rankSelection(pop []*Individual, count int) []*Individual {
+func rankSelection(pop []*Individual, costs []float64, count int, scaling *proto.FitnessScaling, rng *rand.Rand) []*Individual {
 	parents := make([]*Individual, count)
-	sorted := make([]*Individual, len(pop))
-	copy(sorted, pop)
+	sorted := make([]int, len(pop))
+	for i := range sorted {
+		sorted[i] = i
+	}
 	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Fitness < sorted[j].Fitness
+		return costs[sorted[i]] < costs[sorted[j]]
 	})
-	n := len(sorted)
-	rankSum := float64(n*(n+1)) / 2.0
+	weights := logic.RankWeights(len(sorted), scaling)
+	rankSum := 0.0
+	for _, w := range weights {
+		rankSum += w
+	}
 	for i := 0; i < count; i++ {
-		r := rand.Float64() * rankSum
+		r := rng.Float64() * rankSum
 		rank := 0.0
-		for j, p := range sorted {
-			rank += float64(n - j)
-			if rank >= r {
-				parents[i] = p
+		for j, k := range sorted {
+			rank += weights[j]
+			if weights[j] > 0 && rank >= r {
+				parents[i] = pop[k]
 				break
 			}
 		}
@@ -0,0 +1,52 @@
+package ga_level1
+
+import (
+	"context"
+
+	"noytech-ga-optimizer/api/proto"
+)
+
+// stopper проверяет условия остановки ГА 1-го уровня после каждого поколения.
+type stopper struct {
+	settings *proto.GASettings
+	runCtx   context.Context // Контекст с бюджетом max_duration_ms
+	history  []float64       // Лучшее значение fitness после каждого поколения
+}
+
+// check возвращает условие, по которому ГА нужно остановить после поколения gen,
+// или STOP_REASON_UNSPECIFIED, если прогон продолжается.
+func (s *stopper) check(gen int, best *Individual, noImprove int, individuals []*Individual, evaluations int64) proto.StopReason {
+	s.history = append(s.history, best.Fitness)
+	c := s.settings.StopConditions
+
+	switch {
+	case c.GetTargetCost() > 0 && best.Fitness <= c.GetTargetCost():
+		return proto.StopReason_STOP_TARGET_COST
+	case noImprove >= int(s.settings.StoppingCriterion):
+		return proto.StopReason_STOP_NO_IMPROVEMENT
+	case c.GetMinRelativeImprovement() > 0 && s.stalled(int(c.GetImprovementWindow()), c.GetMinRelativeImprovement()):
+		return proto.StopReason_STOP_MIN_IMPROVEMENT
+	case c.GetMinDiversity() > 0 && diversity(individuals) < c.GetMinDiversity():
+		return proto.StopReason_STOP_MIN_DIVERSITY
+	case c.GetMaxEvaluations() > 0 && evaluations >= c.GetMaxEvaluations():
+		return proto.StopReason_STOP_MAX_EVALUATIONS
+	case s.runCtx.Err() != nil:
+		return proto.StopReason_STOP_TIME_LIMIT
+	case gen >= int(s.settings.NumGenerations):
+		return proto.StopReason_STOP_NUM_GENERATIONS
+	}
+	return proto.StopReason_STOP_REASON_UNSPECIFIED
+}
+
+// stalled сообщает, что лучшая стоимость за последние window поколений снизилась меньше чем на долю eps.
+func (s *stopper) stalled(window int, eps float64) bool {
+	last := len(s.history) - 1
+	if window <= 0 || last < window {
+		return false
+	}
+	before := s.history[last-window]
+	if before <= 0 {
+		return false
+	}
+	return (before-s.history[last])/before < eps
+}
@@ -0,0 +1,72 @@
+package ga_level1
+
+import (
+	"context"
+	"testing"
+
+	"noytech-ga-optimizer/api/proto"
+)
+
+func TestStopperCheck(t *testing.T) {
+	same := []*Individual{{TerminalMask: []bool{true, false}}, {TerminalMask: []bool{true, false}}}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name        string
+		conditions  *proto.StopConditions
+		runCtx      context.Context
+		gen         int
+		noImprove   int
+		evaluations int64
+		want        proto.StopReason
+	}{
+		{"continues", nil, context.Background(), 1, 0, 0, proto.StopReason_STOP_REASON_UNSPECIFIED},
+		{"num generations", nil, context.Background(), 10, 0, 0, proto.StopReason_STOP_NUM_GENERATIONS},
+		{"no improvement", nil, context.Background(), 1, 5, 0, proto.StopReason_STOP_NO_IMPROVEMENT},
+		{"target cost", &proto.StopConditions{TargetCost: 100}, context.Background(), 1, 0, 0, proto.StopReason_STOP_TARGET_COST},
+		{"target cost not reached", &proto.StopConditions{TargetCost: 99}, context.Background(), 1, 0, 0, proto.StopReason_STOP_REASON_UNSPECIFIED},
+		{"min diversity", &proto.StopConditions{MinDiversity: 0.1}, context.Background(), 1, 0, 0, proto.StopReason_STOP_MIN_DIVERSITY},
+		{"max evaluations", &proto.StopConditions{MaxEvaluations: 50}, context.Background(), 1, 0, 50, proto.StopReason_STOP_MAX_EVALUATIONS},
+		{"time limit", nil, cancelled, 1, 0, 0, proto.StopReason_STOP_TIME_LIMIT},
+		// При нескольких сработавших условиях причина — первое по порядку проверки
+		{"target cost before generations", &proto.StopConditions{TargetCost: 100}, cancelled, 10, 5, 0, proto.StopReason_STOP_TARGET_COST},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &stopper{
+				settings: &proto.GASettings{NumGenerations: 10, StoppingCriterion: 5, StopConditions: tt.conditions},
+				runCtx:   tt.runCtx,
+			}
+			got := s.check(tt.gen, &Individual{Fitness: 100}, tt.noImprove, same, tt.evaluations)
+			if got != tt.want {
+				t.Errorf("check = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStopperStalled(t *testing.T) {
+	tests := []struct {
+		name    string
+		history []float64
+		window  int
+		eps     float64
+		want    bool
+	}{
+		{"history shorter than window", []float64{100, 100}, 2, 0.01, false},
+		{"improved enough", []float64{100, 99, 98}, 2, 0.01, false},
+		{"improved too little", []float64{100, 99.9, 99.5}, 2, 0.01, true},
+		{"only the window counts", []float64{200, 100, 100, 100}, 2, 0.01, true},
+		{"zero window", []float64{100, 100, 100}, 0, 0.01, false},
+		{"non-positive cost", []float64{0, 0, 0}, 2, 0.01, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &stopper{history: tt.history}
+			if got := s.stalled(tt.window, tt.eps); got != tt.want {
+				t.Errorf("stalled(%d, %v) = %v, want %v", tt.window, tt.eps, got, tt.want)
+			}
+		})
+	}
+}
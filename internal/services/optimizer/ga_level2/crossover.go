@@ -0,0 +1,60 @@
+package ga_level2
+
+import (
+	"math/rand"
+	"noytech-ga-optimizer/api/proto"
+)
+
+// Crossover скрещивает назначения грузов и выбор ТС независимо, одним и тем же методом.
+func Crossover(p1, p2 *Individual, method proto.CrossoverType, rng *rand.Rand) (*Individual, *Individual) {
+	var op func(a, b []int, rng *rand.Rand) ([]int, []int)
+	switch method {
+	case proto.CrossoverType_CROSSOVER_UNIFORM:
+		op = uniformCrossover
+	case proto.CrossoverType_CROSSOVER_SINGLE_POINT:
+		op = singlePointCrossover
+	case proto.CrossoverType_CROSSOVER_TWO_POINT:
+		op = twoPointCrossover
+	default:
+		panic("unsupported crossover type")
+	}
+	a1, a2 := op(p1.Assignment, p2.Assignment, rng)
+	v1, v2 := op(p1.Vehicles, p2.Vehicles, rng)
+	return &Individual{Assignment: a1, Vehicles: v1}, &Individual{Assignment: a2, Vehicles: v2}
+}
+
+func uniformCrossover(a, b []int, rng *rand.Rand) ([]int, []int) {
+	c1, c2 := make([]int, len(a)), make([]int, len(a))
+	for i := range a {
+		if rng.Float64() < 0.5 {
+			c1[i], c2[i] = a[i], b[i]
+		} else {
+			c1[i], c2[i] = b[i], a[i]
+		}
+	}
+	return c1, c2
+}
+
+func singlePointCrossover(a, b []int, rng *rand.Rand) ([]int, []int) {
+	if len(a) <= 1 {
+		return append([]int(nil), a...), append([]int(nil), b...)
+	}
+	i := rng.Intn(len(a)-1) + 1
+	c1 := append(append([]int{}, a[:i]...), b[i:]...)
+	c2 := append(append([]int{}, b[:i]...), a[i:]...)
+	return c1, c2
+}
+
+func twoPointCrossover(a, b []int, rng *rand.Rand) ([]int, []int) {
+	n := len(a)
+	if n <= 2 {
+		return append([]int(nil), a...), append([]int(nil), b...)
+	}
+	i, j := rng.Intn(n), rng.Intn(n)
+	if i > j {
+		i, j = j, i
+	}
+	c1 := append(append(append([]int{}, a[:i]...), b[i:j]...), a[j:]...)
+	c2 := append(append(append([]int{}, b[:i]...), a[i:j]...), b[j:]...)
+	return c1, c2
+}
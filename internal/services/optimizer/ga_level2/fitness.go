@@ -1,111 +1,78 @@
 package ga_level2
 
 import (
-	"math"
-	"noytech-ga-optimizer/api/proto"
 	"noytech-ga-optimizer/internal/models"
 	"noytech-ga-optimizer/internal/services/optimizer/logic"
+	"sort"
 )
 
 func CalculateFitnessLevel2(
+	ind *Individual,
 	activeTerminals []models.Terminal,
 	shipments []models.Shipment,
 	interCityRates []models.InterCityRate,
 	intraCityRates []models.IntraCityRate,
 	distances map[string]map[string]int,
-) (*Individual, error) {
-	ind := &Individual{}
-
-	// 1. Распределение грузов по ближайшему терминалу
+	fleet logic.Fleet,
+) error {
+	// 1. Распределение грузов по терминалам согласно генотипу
 	penalty := 0.0
-	terminalShipments := make(map[string][]models.Shipment)
-	for _, s := range shipments {
-		bestCity := ""
-		minDist := 1<<31 - 1
-		for _, t := range activeTerminals {
-			if distMap, ok := distances[t.City]; ok {
-				if d, ok2 := distMap[s.DestinationCity]; ok2 && d < minDist {
-					minDist = d
-					bestCity = t.City
-				}
-			}
+	terminalShipments := make([][]models.Shipment, len(activeTerminals))
+	for i, s := range shipments {
+		idx := ind.Assignment[i]
+		if idx < 0 {
+			penalty += 1e9
+			continue
 		}
-		if bestCity == "" {
+		if _, ok := distances[activeTerminals[idx].City][s.DestinationCity]; !ok {
 			penalty += 1e9
 			continue
 		}
-		terminalShipments[bestCity] = append(terminalShipments[bestCity], s)
+		terminalShipments[idx] = append(terminalShipments[idx], s)
 	}
 
 	// 2. Last-mile cost
 	lastMileCost := 0.0
-	for city, sList := range terminalShipments {
+	for j, sList := range terminalShipments {
+		city := activeTerminals[j].City
 		cost, err := logic.CalculateLastMileCostForTerminal(
 			sList, city, interCityRates, intraCityRates, distances[city])
 		if err != nil {
-			return nil, err
+			return err
 		}
 		lastMileCost += cost
 	}
 
-	// 3. Linehaul cost (Москва -> каждый терминал отдельно)
+	// 3. Раскладка потока по ТС не крупнее выбранного класса и linehaul cost (за каждый рейс).
+	// Терминалы делят автопарк дня в порядке activeTerminals.
 	linehaulCost := 0.0
-	for _, t := range activeTerminals {
-		cost, err := logic.CalculateLinehaulCost(t, activeTerminals, interCityRates)
-		if err != nil {
-			return nil, err
-		}
-		linehaulCost += cost
-	}
-
-	// 4. Штрафы и подбор ТС
 	routes := make([]RouteWithShipments, 0)
-	for city, sList := range terminalShipments {
-		totalWeightTons := 0.0
-		totalVolumeM3 := 0.0
-		ids := make([]string, 0)
-		for _, s := range sList {
-			totalWeightTons += s.WeightKg / 1000.0
-			totalVolumeM3 += s.VolumeM3
-			ids = append(ids, s.ID)
-		}
-
-		var chosenType proto.TransportType
-		var capTons, capM3 float64
-		found := false
-		for _, tr := range logic.AvailableTransports {
-			if totalWeightTons <= tr.CapTons && totalVolumeM3 <= tr.CapM3 {
-				chosenType = tr.Type
-				capTons = tr.CapTons
-				capM3 = tr.CapM3
-				found = true
-				break
-			}
+	pool := fleet.NewPool()
+	for j, t := range activeTerminals {
+		terminalRate, err := logic.CalculateLinehaulCost(t, activeTerminals, interCityRates)
+		if err != nil {
+			return err
 		}
 
-		if !found {
-			last := logic.AvailableTransports[len(logic.AvailableTransports)-1]
-			chosenType = last.Type
-			capTons = last.CapTons
-			capM3 = last.CapM3
-			penalty += 50000
+		loads := logic.AllocateVehicles(terminalShipments[j], fleet.Vehicles[:ind.Vehicles[j]+1], pool)
+		if len(loads) == 0 {
+			linehaulCost += terminalRate
 		}
 
-		utilWeight := totalWeightTons / capTons
-		utilVolume := totalVolumeM3 / capM3
-		utilization := math.Max(utilWeight, utilVolume)
+		// 4. Штрафы за негабарит, нехватку ТС и недозагрузку
+		penalty += logic.LoadPenalty(loads)
 
-		if utilization < 0.6 {
-			penalty += 10000 * (0.6 - utilization)
+		for _, load := range loads {
+			tripCost := logic.TripCost(terminalRate, load.Vehicle, t)
+			linehaulCost += tripCost
+			routes = append(routes, RouteWithShipments{
+				FromCity:    "Москва",
+				ToTerminal:  t.City,
+				ShipmentIDs: load.ShipmentIDs(),
+				Cost:        tripCost,
+				VehicleID:   load.Vehicle.ID,
+			})
 		}
-
-		routes = append(routes, RouteWithShipments{
-			FromCity:      "Москва",
-			ToTerminal:    city,
-			ShipmentIDs:   ids,
-			Cost:          0,
-			TransportUsed: chosenType,
-		})
 	}
 
 	totalCost := linehaulCost + lastMileCost + penalty
@@ -123,5 +90,47 @@ func CalculateFitnessLevel2(
 	}
 	ind.Routes = routes
 
-	return ind, nil
+	return nil
+}
+
+// candidateTerminals возвращает для каждого груза индексы активных терминалов,
+// от которых известно расстояние до города назначения, по возрастанию расстояния.
+func candidateTerminals(
+	activeTerminals []models.Terminal,
+	shipments []models.Shipment,
+	distances map[string]map[string]int,
+) [][]int {
+	candidates := make([][]int, len(shipments))
+	for i, s := range shipments {
+		list := make([]int, 0, len(activeTerminals))
+		for j, t := range activeTerminals {
+			if _, ok := distances[t.City][s.DestinationCity]; ok {
+				list = append(list, j)
+			}
+		}
+		sort.SliceStable(list, func(a, b int) bool {
+			return distances[activeTerminals[list[a]].City][s.DestinationCity] <
+				distances[activeTerminals[list[b]].City][s.DestinationCity]
+		})
+		candidates[i] = list
+	}
+	return candidates
+}
+
+// nearestIndividual — назначение каждого груза на ближайший терминал без ограничения класса ТС.
+func nearestIndividual(shipments []models.Shipment, numTerminals, fleetSize int, candidates [][]int) *Individual {
+	ind := &Individual{
+		Assignment: make([]int, len(shipments)),
+		Vehicles:   make([]int, numTerminals),
+	}
+	for i := range shipments {
+		ind.Assignment[i] = -1
+		if len(candidates[i]) > 0 {
+			ind.Assignment[i] = candidates[i][0]
+		}
+	}
+	for j := range ind.Vehicles {
+		ind.Vehicles[j] = fleetSize - 1
+	}
+	return ind
 }
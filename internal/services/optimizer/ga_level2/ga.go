@@ -1,28 +1,122 @@
 package ga_level2
 
 import (
+	"context"
+	"math/rand"
+
+	"noytech-ga-optimizer/api/proto"
 	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
 )
 
+// Result — итог прогона ГА 2-го уровня.
+type Result struct {
+	Best        *Individual
+	TimeLimited bool // Прогон остановлен по max_duration_ms
+}
+
+// RunGALevel2 подбирает назначение грузов на терминалы и ТС для каждого маршрута
+// при фиксированном наборе терминалов из 1-го уровня. Без settings возвращает
+// назначение на ближайшие терминалы с минимальными подходящими ТС.
 func RunGALevel2(
+	ctx context.Context,
+	settings *proto.GASettings,
+	rng *rand.Rand,
+	workers int,
 	activeTerminals []models.Terminal,
 	shipments []models.Shipment,
 	interCityRates []models.InterCityRate,
 	intraCityRates []models.IntraCityRate,
 	distances map[string]map[string]int,
-) (*Individual, error) {
+	fleet logic.Fleet,
+) (*Result, error) {
 	if len(activeTerminals) == 0 {
-		return &Individual{
+		return &Result{Best: &Individual{
 			Cost:    CostBreakdown{TotalCost: 1e12},
 			Fitness: 1e12,
-		}, nil
+		}}, nil
+	}
+
+	size := 1
+	if settings != nil {
+		size = int(settings.NumIndividuals)
+	}
+
+	pop := NewPopulation(size, activeTerminals, shipments, distances, fleet, rng)
+	if err := pop.Evaluate(ctx, workers, interCityRates, intraCityRates, distances); err != nil {
+		return nil, err
+	}
+
+	best := pop.GetBest()
+	if settings == nil {
+		return &Result{Best: best}, nil
+	}
+
+	runCtx, cancel := logic.WithBudget(ctx, settings.MaxDurationMs)
+	defer cancel()
+
+	result := &Result{}
+	noImprove := 0
+	mutationRate, crossoverRate := logic.Rates(settings)
+
+	for gen := 0; gen < int(settings.NumGenerations); gen++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		currentBest := pop.GetBest()
+		if currentBest.Fitness < best.Fitness {
+			best = currentBest
+			noImprove = 0
+		} else {
+			noImprove++
+		}
+
+		if noImprove >= int(settings.StoppingCriterion) {
+			break
+		}
+
+		// Элита переходит в следующее поколение без изменений, остальные места занимают потомки
+		elite := logic.Elite(pop.Individuals, int(settings.EliteCount), byFitness)
+		numChildren := len(pop.Individuals) - len(elite)
+
+		parents := SelectParents(pop.Individuals, numChildren, settings, rng)
+		children := make([]*Individual, 0, numChildren+1)
+
+		for i := 0; i < len(parents); i += 2 {
+			p1 := parents[i]
+			p2 := parents[(i+1)%len(parents)]
+			var child1, child2 *Individual
+			if crossoverRate >= 1 || rng.Float64() < crossoverRate {
+				child1, child2 = Crossover(p1, p2, settings.CrossoverType, rng)
+			} else {
+				child1, child2 = p1.Clone(), p2.Clone()
+			}
+			pop.Mutate(child1, mutationRate, settings.MutationType, rng)
+			pop.Mutate(child2, mutationRate, settings.MutationType, rng)
+			pop.Repair(child1)
+			pop.Repair(child2)
+			children = append(children, child1, child2)
+		}
+
+		if len(children) > numChildren {
+			children = children[:numChildren]
+		}
+
+		if err := pop.evaluate(runCtx, workers, children, interCityRates, intraCityRates, distances); err != nil {
+			if logic.BudgetExceeded(ctx, runCtx) {
+				result.TimeLimited = true
+				break
+			}
+			return nil, err
+		}
+		pop.Individuals = append(elite, children...)
+	}
+
+	if currentBest := pop.GetBest(); currentBest.Fitness < best.Fitness {
+		best = currentBest
 	}
 
-	return CalculateFitnessLevel2(
-		activeTerminals,
-		shipments,
-		interCityRates,
-		intraCityRates,
-		distances,
-	)
+	result.Best = best
+	return result, nil
 }
@@ -0,0 +1,104 @@
+package ga_level2
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
+)
+
+// testDay — два терминала и грузы, которым не хватает одного ТС на терминал.
+type testDay struct {
+	terminals      []models.Terminal
+	shipments      []models.Shipment
+	interCityRates []models.InterCityRate
+	intraCityRates []models.IntraCityRate
+	distances      map[string]map[string]int
+}
+
+func newTestDay() testDay {
+	d := testDay{
+		terminals: []models.Terminal{
+			{City: "A", DistanceFromMoscowKm: 100},
+			{City: "B", DistanceFromMoscowKm: 300},
+		},
+		interCityRates: []models.InterCityRate{{VolumeM3: 100, WeightTons: 30, RatePerKm: 50}},
+		intraCityRates: []models.IntraCityRate{{VolumeM3: 100, WeightTons: 30, RateFixed: 1000}},
+		distances:      map[string]map[string]int{"A": {}, "B": {}},
+	}
+	for i := range 12 {
+		city := fmt.Sprintf("c%d", i%6)
+		d.distances["A"][city] = 10 + 20*(i%6)
+		d.distances["B"][city] = 110 - 20*(i%6)
+		d.shipments = append(d.shipments, models.Shipment{
+			ID: fmt.Sprintf("s%d", i), WeightKg: 2000, VolumeM3: 10, DestinationCity: city,
+		})
+	}
+	return d
+}
+
+func (d testDay) run(t *testing.T, settings *proto.GASettings, fleet logic.Fleet, seed int64) *Individual {
+	t.Helper()
+	result, err := RunGALevel2(context.Background(), settings, rand.New(rand.NewSource(seed)), 4, d.terminals, d.shipments,
+		d.interCityRates, d.intraCityRates, d.distances, fleet)
+	if err != nil {
+		t.Fatalf("RunGALevel2: %v", err)
+	}
+	return result.Best
+}
+
+var testSettings = &proto.GASettings{
+	NumGenerations:    30,
+	NumIndividuals:    20,
+	SelectionType:     proto.SelectionType_SELECTION_TOURNAMENT,
+	CrossoverType:     proto.CrossoverType_CROSSOVER_SINGLE_POINT,
+	MutationType:      proto.MutationType_MUTATION_SWAP,
+	StoppingCriterion: 30,
+}
+
+func TestRunGALevel2RespectsFleetLimits(t *testing.T) {
+	fleet := logic.Fleet{
+		Vehicles: []models.Vehicle{
+			{ID: "3t", CapacityTons: 3, CapacityM3: 20},
+			{ID: "10t", CapacityTons: 10, CapacityM3: 45},
+			{ID: "20t", CapacityTons: 20, CapacityM3: 86},
+		},
+		Limits: map[string]int{"10t": 1, "20t": 1},
+	}
+
+	for _, settings := range []*proto.GASettings{nil, testSettings} {
+		best := newTestDay().run(t, settings, fleet, 1)
+
+		used := make(map[string]int)
+		shipments := 0
+		for _, r := range best.Routes {
+			used[r.VehicleID]++
+			shipments += len(r.ShipmentIDs)
+		}
+		for id, limit := range fleet.Limits {
+			if used[id] > limit {
+				t.Errorf("plan uses %d vehicles of class %s, only %d available", used[id], id, limit)
+			}
+		}
+		if shipments != 12 {
+			t.Errorf("plan routes carry %d shipments, want 12", shipments)
+		}
+	}
+}
+
+func TestRunGALevel2IsReproducible(t *testing.T) {
+	fleet := logic.Fleet{Vehicles: []models.Vehicle{
+		{ID: "3t", CapacityTons: 3, CapacityM3: 20},
+		{ID: "20t", CapacityTons: 20, CapacityM3: 86},
+	}}
+	first := newTestDay().run(t, testSettings, fleet, 42)
+	second := newTestDay().run(t, testSettings, fleet, 42)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("runs with the same seed differ:\n%+v\n%+v", first, second)
+	}
+}
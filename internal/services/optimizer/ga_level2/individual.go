@@ -1,8 +1,12 @@
 package ga_level2
 
-import "noytech-ga-optimizer/api/proto"
-
+// Individual — решение 2-го уровня для фиксированного набора терминалов.
+// Assignment[i] — индекс активного терминала для i-го груза (-1, если груз не назначен),
+// Vehicles[j] — индекс в справочнике автопарка (по возрастанию вместимости) самого крупного класса ТС, который можно
+// использовать для рейсов на j-й терминал (поток раскладывается на несколько ТС не крупнее него).
 type Individual struct {
+	Assignment      []int
+	Vehicles        []int
 	Fitness         float64
 	Cost            CostBreakdown
 	ActiveTerminals []string
@@ -10,11 +14,11 @@ type Individual struct {
 }
 
 type RouteWithShipments struct {
-	FromCity      string
-	ToTerminal    string
-	ShipmentIDs   []string
-	Cost          float64
-	TransportUsed proto.TransportType
+	FromCity    string
+	ToTerminal  string
+	ShipmentIDs []string
+	Cost        float64
+	VehicleID   string // Класс ТС из справочника автопарка
 }
 
 type CostBreakdown struct {
@@ -23,3 +27,15 @@ type CostBreakdown struct {
 	PenaltyCost  float64
 	TotalCost    float64
 }
+
+func byFitness(ind *Individual) float64 {
+	return ind.Fitness
+}
+
+// Clone копирует генотип особи; fitness и маршруты пересчитываются при оценке.
+func (ind *Individual) Clone() *Individual {
+	return &Individual{
+		Assignment: append([]int(nil), ind.Assignment...),
+		Vehicles:   append([]int(nil), ind.Vehicles...),
+	}
+}
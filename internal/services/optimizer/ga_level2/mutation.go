@@ -0,0 +1,61 @@
+package ga_level2
+
+import (
+	"math/rand"
+	"noytech-ga-optimizer/api/proto"
+)
+
+// Mutate переставляет гены назначений и выбора ТС, а затем меняет назначение случайного груза
+// и класс ТС случайного терминала: перестановка сама по себе не вводит новых значений генов.
+// Недопустимые после мутации гены исправляет Population.Repair.
+func (p *Population) Mutate(ind *Individual, prob float64, method proto.MutationType, rng *rand.Rand) {
+	if rng.Float64() > prob {
+		return
+	}
+	switch method {
+	case proto.MutationType_MUTATION_INVERSION:
+		inversion(ind.Assignment, rng)
+		inversion(ind.Vehicles, rng)
+	case proto.MutationType_MUTATION_SWAP:
+		swap(ind.Assignment, rng)
+		swap(ind.Vehicles, rng)
+	default:
+		panic("unsupported mutation type")
+	}
+	p.reassign(ind, rng)
+}
+
+// reassign назначает случайный груз на один из candidatePoolSize ближайших терминалов,
+// а случайному терминалу — случайный класс ТС.
+func (p *Population) reassign(ind *Individual, rng *rand.Rand) {
+	if len(ind.Assignment) > 0 {
+		i := rng.Intn(len(ind.Assignment))
+		if n := min(len(p.Candidates[i]), candidatePoolSize); n > 0 {
+			ind.Assignment[i] = p.Candidates[i][rng.Intn(n)]
+		}
+	}
+	if len(ind.Vehicles) > 0 && len(p.Fleet.Vehicles) > 0 {
+		ind.Vehicles[rng.Intn(len(ind.Vehicles))] = rng.Intn(len(p.Fleet.Vehicles))
+	}
+}
+
+func inversion(genes []int, rng *rand.Rand) {
+	if len(genes) < 2 {
+		return
+	}
+	i, j := rng.Intn(len(genes)), rng.Intn(len(genes))
+	if i > j {
+		i, j = j, i
+	}
+	for a, b := i, j; a < b; a, b = a+1, b-1 {
+		genes[a], genes[b] = genes[b], genes[a]
+	}
+}
+
+func swap(genes []int, rng *rand.Rand) {
+	if len(genes) < 2 {
+		return
+	}
+	i, j := rng.Intn(len(genes)), rng.Intn(len(genes))
+	genes[i], genes[j] = genes[j], genes[i]
+}
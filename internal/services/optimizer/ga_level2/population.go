@@ -0,0 +1,124 @@
+package ga_level2
+
+import (
+	"context"
+	"math/rand"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
+)
+
+// candidatePoolSize — из скольких ближайших терминалов выбирается назначение груза в случайной особи.
+const candidatePoolSize = 3
+
+type Population struct {
+	Individuals     []*Individual
+	ActiveTerminals []models.Terminal
+	Shipments       []models.Shipment
+	Fleet           logic.Fleet
+	Candidates      [][]int  // Достижимые терминалы каждого груза по возрастанию расстояния
+	reachable       [][]bool // reachable[i][j] — от j-го терминала известно расстояние до i-го груза
+}
+
+// NewPopulation создаёт популяцию 2-го уровня. Первая особь — назначение на ближайшие терминалы,
+// остальные назначают каждый груз на случайный из candidatePoolSize ближайших терминалов.
+func NewPopulation(
+	size int,
+	activeTerminals []models.Terminal,
+	shipments []models.Shipment,
+	distances map[string]map[string]int,
+	fleet logic.Fleet,
+	rng *rand.Rand,
+) *Population {
+	candidates := candidateTerminals(activeTerminals, shipments, distances)
+	reachable := make([][]bool, len(shipments))
+	for i, list := range candidates {
+		reachable[i] = make([]bool, len(activeTerminals))
+		for _, j := range list {
+			reachable[i][j] = true
+		}
+	}
+
+	pop := &Population{
+		Individuals:     make([]*Individual, size),
+		ActiveTerminals: activeTerminals,
+		Shipments:       shipments,
+		Fleet:           fleet,
+		Candidates:      candidates,
+		reachable:       reachable,
+	}
+	if size == 0 {
+		return pop
+	}
+
+	pop.Individuals[0] = nearestIndividual(shipments, len(activeTerminals), len(fleet.Vehicles), candidates)
+	for k := 1; k < size; k++ {
+		ind := &Individual{
+			Assignment: make([]int, len(shipments)),
+			Vehicles:   make([]int, len(activeTerminals)),
+		}
+		for i := range shipments {
+			ind.Assignment[i] = -1
+			if n := min(len(candidates[i]), candidatePoolSize); n > 0 {
+				ind.Assignment[i] = candidates[i][rng.Intn(n)]
+			}
+		}
+		for j := range ind.Vehicles {
+			ind.Vehicles[j] = rng.Intn(len(fleet.Vehicles))
+		}
+		pop.Individuals[k] = ind
+	}
+	return pop
+}
+
+func (p *Population) Evaluate(
+	ctx context.Context,
+	workers int,
+	interCityRates []models.InterCityRate,
+	intraCityRates []models.IntraCityRate,
+	distances map[string]map[string]int,
+) error {
+	return p.evaluate(ctx, workers, p.Individuals, interCityRates, intraCityRates, distances)
+}
+
+// evaluate считает fitness особей параллельно на workers горутинах.
+func (p *Population) evaluate(
+	ctx context.Context,
+	workers int,
+	individuals []*Individual,
+	interCityRates []models.InterCityRate,
+	intraCityRates []models.IntraCityRate,
+	distances map[string]map[string]int,
+) error {
+	return logic.ForEach(ctx, len(individuals), workers, func(i int) error {
+		return CalculateFitnessLevel2(individuals[i], p.ActiveTerminals, p.Shipments, interCityRates, intraCityRates, distances, p.Fleet)
+	})
+}
+
+func (p *Population) GetBest() *Individual {
+	best := p.Individuals[0]
+	for _, ind := range p.Individuals[1:] {
+		if ind.Fitness < best.Fitness {
+			best = ind
+		}
+	}
+	return best
+}
+
+// Repair возвращает гены в допустимую область после скрещивания и мутации:
+// груз, попавший на терминал без известного расстояния, переназначается на ближайший.
+func (p *Population) Repair(ind *Individual) {
+	for i, idx := range ind.Assignment {
+		if idx >= 0 && p.reachable[i][idx] {
+			continue
+		}
+		ind.Assignment[i] = -1
+		if len(p.Candidates[i]) > 0 {
+			ind.Assignment[i] = p.Candidates[i][0]
+		}
+	}
+	for j, v := range ind.Vehicles {
+		if v < 0 || v >= len(p.Fleet.Vehicles) {
+			ind.Vehicles[j] = len(p.Fleet.Vehicles) - 1
+		}
+	}
+}
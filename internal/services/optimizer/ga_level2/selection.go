@@ -0,0 +1,89 @@
+package ga_level2
+
+import (
+	"math/rand"
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/services/optimizer/logic"
+	"sort"
+)
+
+func SelectParents(pop []*Individual, count int, settings *proto.GASettings, rng *rand.Rand) []*Individual {
+	switch settings.SelectionType {
+	case proto.SelectionType_SELECTION_TOURNAMENT:
+		return tournamentSelection(pop, count, logic.TournamentSize(settings), rng)
+	case proto.SelectionType_SELECTION_ROULETTE:
+		return rouletteWheelSelection(pop, count, settings.Scaling, rng)
+	case proto.SelectionType_SELECTION_RANK:
+		return rankSelection(pop, count, settings.Scaling, rng)
+	default:
+		panic("unsupported selection type")
+	}
+}
+
+func tournamentSelection(pop []*Individual, count, tSize int, rng *rand.Rand) []*Individual {
+	parents := make([]*Individual, count)
+	for i := 0; i < count; i++ {
+		best := pop[rng.Intn(len(pop))]
+		for j := 1; j < tSize; j++ {
+			if p := pop[rng.Intn(len(pop))]; p.Fitness < best.Fitness {
+				best = p
+			}
+		}
+		parents[i] = best
+	}
+	return parents
+}
+
+func rouletteWheelSelection(pop []*Individual, count int, scaling *proto.FitnessScaling, rng *rand.Rand) []*Individual {
+	parents := make([]*Individual, count)
+	costs := make([]float64, len(pop))
+	for k, p := range pop {
+		costs[k] = p.Fitness
+	}
+	weights := logic.RouletteWeights(costs, scaling)
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	for i := 0; i < count; i++ {
+		r := rng.Float64() * total
+		cum := 0.0
+		parents[i] = pop[len(pop)-1]
+		for k, p := range pop {
+			cum += weights[k]
+			if weights[k] > 0 && cum >= r {
+				parents[i] = p
+				break
+			}
+		}
+	}
+	return parents
+}
+
+func rankSelection(pop []*Individual, count int, scaling *proto.FitnessScaling, rng *rand.Rand) []*Individual {
+	parents := make([]*Individual, count)
+	sorted := make([]*Individual, len(pop))
+	copy(sorted, pop)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Fitness < sorted[j].Fitness
+	})
+	n := len(sorted)
+	weights := logic.RankWeights(n, scaling)
+	rankSum := 0.0
+	for _, w := range weights {
+		rankSum += w
+	}
+	for i := 0; i < count; i++ {
+		r := rng.Float64() * rankSum
+		rank := 0.0
+		parents[i] = sorted[n-1]
+		for j, p := range sorted {
+			rank += weights[j]
+			if weights[j] > 0 && rank >= r {
+				parents[i] = p
+				break
+			}
+		}
+	}
+	return parents
+}
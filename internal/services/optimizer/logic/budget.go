@@ -0,0 +1,21 @@
+package logic
+
+import (
+	"context"
+	"time"
+)
+
+// WithBudget ограничивает прогон ГА maxDurationMs миллисекундами (0 — без ограничения).
+// Отмена ctx по-прежнему останавливает прогон.
+func WithBudget(ctx context.Context, maxDurationMs int32) (context.Context, context.CancelFunc) {
+	if maxDurationMs <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(maxDurationMs)*time.Millisecond)
+}
+
+// BudgetExceeded сообщает, что runCtx из WithBudget остановлен бюджетом времени, а не отменой ctx:
+// в этом случае прогон возвращает лучшее найденное решение вместо ошибки.
+func BudgetExceeded(ctx, runCtx context.Context) bool {
+	return ctx.Err() == nil && runCtx.Err() != nil
+}
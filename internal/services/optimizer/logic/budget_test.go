@@ -0,0 +1,49 @@
+package logic
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBudgetExceeded(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxDurationMs int32
+		cancelParent  bool
+		want          bool
+	}{
+		{"budget elapsed", 1, false, true},
+		{"parent cancelled without budget", 0, true, false},
+		{"parent cancelled within budget", 60_000, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancelParent := context.WithCancel(context.Background())
+			defer cancelParent()
+			runCtx, cancel := WithBudget(ctx, tt.maxDurationMs)
+			defer cancel()
+
+			if BudgetExceeded(ctx, runCtx) {
+				t.Fatal("budget exceeded before the run stopped")
+			}
+			if tt.cancelParent {
+				cancelParent()
+			}
+			<-runCtx.Done()
+			if got := BudgetExceeded(ctx, runCtx); got != tt.want {
+				t.Errorf("BudgetExceeded = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithBudgetUnlimited(t *testing.T) {
+	runCtx, cancel := WithBudget(context.Background(), 0)
+	if _, ok := runCtx.Deadline(); ok {
+		t.Error("zero budget sets a deadline")
+	}
+	cancel()
+	if runCtx.Err() == nil {
+		t.Error("cancel does not stop the run context")
+	}
+}
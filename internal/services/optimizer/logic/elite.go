@@ -0,0 +1,13 @@
+package logic
+
+import "sort"
+
+// Elite возвращает count лучших особей по возрастанию cost, не меняя порядок individuals.
+func Elite[T any](individuals []T, count int, cost func(T) float64) []T {
+	sorted := make([]T, len(individuals))
+	copy(sorted, individuals)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return cost(sorted[i]) < cost(sorted[j])
+	})
+	return sorted[:min(count, len(sorted))]
+}
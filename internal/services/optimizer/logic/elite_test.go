@@ -0,0 +1,37 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestElite(t *testing.T) {
+	type ind struct {
+		id   string
+		cost float64
+	}
+	byCost := func(i ind) float64 { return i.cost }
+	population := []ind{{"a", 3}, {"b", 1}, {"c", 2}, {"d", 1}}
+	before := slices.Clone(population)
+
+	ids := func(individuals []ind) []string {
+		out := make([]string, len(individuals))
+		for i, x := range individuals {
+			out[i] = x.id
+		}
+		return out
+	}
+
+	if got, want := ids(Elite(population, 3, byCost)), []string{"b", "d", "c"}; !slices.Equal(got, want) {
+		t.Errorf("Elite(3) = %v, want %v (ties keep population order)", got, want)
+	}
+	if got := Elite(population, 10, byCost); len(got) != len(population) {
+		t.Errorf("Elite(10) returned %d individuals, want all %d", len(got), len(population))
+	}
+	if got := Elite(population, 0, byCost); len(got) != 0 {
+		t.Errorf("Elite(0) = %v, want none", got)
+	}
+	if !slices.Equal(population, before) {
+		t.Errorf("Elite reordered the population: %v", population)
+	}
+}
@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"sort"
+
+	"noytech-ga-optimizer/internal/models"
+)
+
+// Fleet — автопарк на день отгрузки: классы ТС по возрастанию вместимости и лимиты по классам.
+type Fleet struct {
+	Vehicles []models.Vehicle
+	Limits   map[string]int // vehicle_id → доступно ТС на день; класса нет в карте — без ограничений
+}
+
+// NewPool возвращает пустой учёт занятых ТС. Один пул на план дня: терминалы делят общий автопарк.
+func (f Fleet) NewPool() *FleetPool {
+	return &FleetPool{limits: f.Limits, used: make(map[string]int)}
+}
+
+// FleetPool — учёт ТС, уже занятых рейсами плана дня.
+type FleetPool struct {
+	limits map[string]int
+	used   map[string]int
+}
+
+func (p *FleetPool) free(id string) bool {
+	limit, ok := p.limits[id]
+	return !ok || p.used[id] < limit
+}
+
+// Take занимает ТС класса id и сообщает, укладывается ли это в лимит.
+// ТС учитывается и при превышении лимита — превышение считается нарушением.
+func (p *FleetPool) Take(id string) bool {
+	ok := p.free(id)
+	p.used[id]++
+	return ok
+}
+
+// Available — классы из vehicles, у которых остались свободные ТС.
+func (p *FleetPool) Available(vehicles []models.Vehicle) []models.Vehicle {
+	available := make([]models.Vehicle, 0, len(vehicles))
+	for _, v := range vehicles {
+		if p.free(v.ID) {
+			available = append(available, v)
+		}
+	}
+	return available
+}
+
+// SortFleet возвращает копию справочника ТС по возрастанию вместимости (тонны, затем м³, затем код).
+// Раскладка грузов и ген класса ТС во 2-м уровне ГА рассчитаны на такой порядок.
+func SortFleet(fleet []models.Vehicle) []models.Vehicle {
+	sorted := make([]models.Vehicle, len(fleet))
+	copy(sorted, fleet)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		if sorted[a].CapacityTons != sorted[b].CapacityTons {
+			return sorted[a].CapacityTons < sorted[b].CapacityTons
+		}
+		if sorted[a].CapacityM3 != sorted[b].CapacityM3 {
+			return sorted[a].CapacityM3 < sorted[b].CapacityM3
+		}
+		return sorted[a].ID < sorted[b].ID
+	})
+	return sorted
+}
+
+// TripCost — стоимость одного рейса ТС до терминала. Если у класса задана стоимость км,
+// она заменяет тариф лайнхола terminalRate; фиксированная стоимость рейса добавляется всегда.
+func TripCost(terminalRate float64, vehicle models.Vehicle, terminal models.Terminal) float64 {
+	cost := terminalRate
+	if vehicle.CostPerKm > 0 {
+		cost = vehicle.CostPerKm * float64(terminal.DistanceFromMoscowKm)
+	}
+	return cost + vehicle.CostPerTrip
+}
@@ -0,0 +1,47 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+
+	"noytech-ga-optimizer/internal/models"
+)
+
+func vehicleIDs(vehicles []models.Vehicle) []string {
+	ids := make([]string, len(vehicles))
+	for i, v := range vehicles {
+		ids[i] = v.ID
+	}
+	return ids
+}
+
+func TestFleetPool(t *testing.T) {
+	fleet := Fleet{Vehicles: testFleet, Limits: map[string]int{"3t": 1, "20t": 0}}
+	pool := fleet.NewPool()
+
+	if got, want := vehicleIDs(pool.Available(testFleet)), []string{"1.5t", "3t", "5t", "10t"}; !slices.Equal(got, want) {
+		t.Fatalf("Available() = %v, want %v", got, want)
+	}
+	if !pool.Take("3t") {
+		t.Error("first Take(3t) = false, want true within the limit")
+	}
+	if pool.Take("3t") {
+		t.Error("second Take(3t) = true, want false over the limit")
+	}
+	if pool.Take("20t") {
+		t.Error("Take(20t) = true, want false for a class with no vehicles")
+	}
+	for range 3 {
+		if !pool.Take("1.5t") {
+			t.Fatal("Take(1.5t) = false, want true for an unlimited class")
+		}
+	}
+	if got, want := vehicleIDs(pool.Available(testFleet)), []string{"1.5t", "5t", "10t"}; !slices.Equal(got, want) {
+		t.Errorf("Available() after Take = %v, want %v", got, want)
+	}
+
+	// Каждый план дня начинает с нового пула
+	if got := vehicleIDs(fleet.NewPool().Available(testFleet)); !slices.Contains(got, "3t") {
+		t.Errorf("new pool Available() = %v, want 3t free again", got)
+	}
+}
@@ -0,0 +1,63 @@
+package logic
+
+import (
+	"context"
+	"sync"
+)
+
+// ForEach вызывает fn(i) для i из [0, n) на workers горутинах до первой ошибки fn или отмены ctx.
+// Каждый вызов пишет только в свой элемент, поэтому результат не зависит от числа воркеров.
+func ForEach(ctx context.Context, n, workers int, fn func(i int) error) error {
+	if workers <= 1 || n < 2 {
+		for i := 0; i < n; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	indices := make(chan int)
+
+	for w := 0; w < min(workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				if err := fn(i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case indices <- i:
+		case <-runCtx.Done():
+			break feed
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,72 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestForEachVisitsEveryIndexOnce(t *testing.T) {
+	for _, workers := range []int{0, 1, 4, 100} {
+		visits := make([]int32, 50)
+		err := ForEach(context.Background(), len(visits), workers, func(i int) error {
+			atomic.AddInt32(&visits[i], 1)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("workers=%d: ForEach() = %v", workers, err)
+		}
+		for i, v := range visits {
+			if v != 1 {
+				t.Errorf("workers=%d: index %d visited %d times, want 1", workers, i, v)
+			}
+		}
+	}
+}
+
+func TestForEachReturnsFirstError(t *testing.T) {
+	errBoom := errors.New("boom")
+	for _, workers := range []int{1, 4} {
+		var calls atomic.Int32
+		err := ForEach(context.Background(), 1000, workers, func(i int) error {
+			calls.Add(1)
+			if i == 3 {
+				return errBoom
+			}
+			return nil
+		})
+		if !errors.Is(err, errBoom) {
+			t.Errorf("workers=%d: ForEach() = %v, want %v", workers, err, errBoom)
+		}
+		if n := calls.Load(); n == 1000 {
+			t.Errorf("workers=%d: all %d indices processed after an error", workers, n)
+		}
+	}
+}
+
+func TestForEachStopsOnCancel(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls atomic.Int32
+		err := ForEach(ctx, 1000, workers, func(i int) error {
+			if calls.Add(1) == 5 {
+				cancel()
+			}
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("workers=%d: ForEach() = %v, want context.Canceled", workers, err)
+		}
+		if n := calls.Load(); n == 1000 {
+			t.Errorf("workers=%d: all %d indices processed after cancel", workers, n)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := ForEach(ctx, 10, 4, func(int) error { return nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ForEach() with cancelled context = %v, want context.Canceled", err)
+	}
+}
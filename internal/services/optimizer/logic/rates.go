@@ -0,0 +1,20 @@
+package logic
+
+import "noytech-ga-optimizer/api/proto"
+
+const (
+	DefaultMutationRate  = 0.1
+	DefaultCrossoverRate = 1.0
+)
+
+// Rates возвращает вероятности мутации и скрещивания из настроек или значения по умолчанию.
+func Rates(settings *proto.GASettings) (mutation, crossover float64) {
+	mutation, crossover = DefaultMutationRate, DefaultCrossoverRate
+	if settings.MutationRate != nil {
+		mutation = *settings.MutationRate
+	}
+	if settings.CrossoverRate != nil {
+		crossover = *settings.CrossoverRate
+	}
+	return mutation, crossover
+}
@@ -0,0 +1,135 @@
+package logic
+
+import (
+	"math"
+
+	"noytech-ga-optimizer/api/proto"
+)
+
+// DefaultTournamentSize — число участников турнира в SELECTION_TOURNAMENT по умолчанию.
+const DefaultTournamentSize = 3
+
+// Параметры масштабирования fitness по умолчанию.
+const (
+	DefaultLinearPressure = 2.0
+	DefaultSigmaC         = 2.0
+	DefaultTemperature    = 1.0
+)
+
+// InfeasibleCost — стоимость решения без открытых терминалов. В рулетке с масштабированием
+// такие особи не получают веса: иначе разброс стоимостей определяется ими, а не остальными особями.
+const InfeasibleCost = 1e12
+
+// TournamentSize возвращает число участников турнира из settings.
+func TournamentSize(settings *proto.GASettings) int {
+	if settings.GetTournamentSize() > 0 {
+		return int(settings.GetTournamentSize())
+	}
+	return DefaultTournamentSize
+}
+
+// RouletteWeights считает веса рулетки по минимизируемым стоимостям: без масштабирования 1/(1+стоимость),
+// с масштабированием особи со стоимостью InfeasibleCost не получают веса, если есть допустимые.
+func RouletteWeights(costs []float64, scaling *proto.FitnessScaling) []float64 {
+	if scaling.GetMethod() == proto.ScalingMethod_SCALING_METHOD_UNSPECIFIED {
+		weights := make([]float64, len(costs))
+		for i, c := range costs {
+			weights[i] = 1.0 / (1.0 + c)
+		}
+		return weights
+	}
+
+	feasible := false
+	for _, c := range costs {
+		if c < InfeasibleCost {
+			feasible = true
+			break
+		}
+	}
+	scores := make([]float64, len(costs))
+	for i, c := range costs {
+		if feasible && c >= InfeasibleCost {
+			scores[i] = math.Inf(-1)
+			continue
+		}
+		scores[i] = -c
+	}
+	return ScaledWeights(scores, scaling)
+}
+
+// RankWeights считает веса ранговой селекции для n особей, отсортированных от лучшей к худшей.
+// Без масштабирования вес j-й особи — n-j.
+func RankWeights(n int, scaling *proto.FitnessScaling) []float64 {
+	weights := make([]float64, n)
+	for j := range weights {
+		weights[j] = float64(n - j)
+	}
+	if scaling.GetMethod() == proto.ScalingMethod_SCALING_METHOD_UNSPECIFIED {
+		return weights
+	}
+	return ScaledWeights(weights, scaling)
+}
+
+// ScaledWeights считает неотрицательные ненормированные веса по оценкам scores (чем выше, тем лучше)
+// методом scaling.Method; оценка -Inf даёт нулевой вес, равные оценки — одинаковые веса.
+func ScaledWeights(scores []float64, scaling *proto.FitnessScaling) []float64 {
+	lo, hi, sum, n := math.Inf(1), math.Inf(-1), 0.0, 0
+	for _, s := range scores {
+		if math.IsInf(s, -1) {
+			continue
+		}
+		lo, hi = min(lo, s), max(hi, s)
+		sum += s
+		n++
+	}
+	if n == 0 {
+		lo, hi = 0, 0
+	}
+	mean := sum / float64(max(n, 1))
+	variance := 0.0
+	for _, s := range scores {
+		if !math.IsInf(s, -1) {
+			variance += (s - mean) * (s - mean)
+		}
+	}
+	sigma := math.Sqrt(variance / float64(max(n, 1)))
+
+	weight := func(s float64) float64 { return 1 }
+	switch {
+	case hi-lo <= 0:
+	case scaling.GetMethod() == proto.ScalingMethod_SCALING_LINEAR:
+		// Линейное масштабирование Голдберга: среднее сохраняется, лучшая особь получает pressure средних весов,
+		// а если худшие веса стали бы отрицательными, прямая проводится через ноль у худшей особи.
+		pressure := DefaultLinearPressure
+		if scaling.GetLinearPressure() > 0 {
+			pressure = scaling.GetLinearPressure()
+		}
+		avg, best := mean-lo, hi-lo
+		a, b := 1.0, 0.0
+		if pressure*avg-best < 0 {
+			a = (pressure - 1) * avg / (best - avg)
+			b = avg * (best - pressure*avg) / (best - avg)
+		}
+		weight = func(s float64) float64 { return max(a*(s-lo)+b, 0) }
+	case scaling.GetMethod() == proto.ScalingMethod_SCALING_SIGMA_TRUNCATION:
+		c := DefaultSigmaC
+		if scaling.GetSigmaC() > 0 {
+			c = scaling.GetSigmaC()
+		}
+		weight = func(s float64) float64 { return max(s-(mean-c*sigma), 0) }
+	case scaling.GetMethod() == proto.ScalingMethod_SCALING_BOLTZMANN:
+		t := DefaultTemperature
+		if scaling.GetTemperature() > 0 {
+			t = scaling.GetTemperature()
+		}
+		weight = func(s float64) float64 { return math.Exp((s - hi) / (t * sigma)) }
+	}
+
+	weights := make([]float64, len(scores))
+	for i, s := range scores {
+		if !math.IsInf(s, -1) {
+			weights[i] = weight(s)
+		}
+	}
+	return weights
+}
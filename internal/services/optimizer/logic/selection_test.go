@@ -0,0 +1,124 @@
+package logic
+
+import (
+	"math"
+	"testing"
+
+	"noytech-ga-optimizer/api/proto"
+)
+
+func TestScaledWeights(t *testing.T) {
+	linear := &proto.FitnessScaling{Method: proto.ScalingMethod_SCALING_LINEAR}
+	sigma := &proto.FitnessScaling{Method: proto.ScalingMethod_SCALING_SIGMA_TRUNCATION}
+	boltzmann := &proto.FitnessScaling{Method: proto.ScalingMethod_SCALING_BOLTZMANN}
+	inf := math.Inf(-1)
+
+	tests := []struct {
+		name    string
+		scores  []float64
+		scaling *proto.FitnessScaling
+		want    []float64 // nil — проверяются только общие свойства весов
+	}{
+		{"linear keeps mean, best gets pressure means", []float64{0, 1, 2, 3, 4},
+			&proto.FitnessScaling{Method: proto.ScalingMethod_SCALING_LINEAR, LinearPressure: 1.5}, []float64{1, 1.5, 2, 2.5, 3}},
+		{"linear keeps mean with a single outlier", []float64{0, 0, 0, 10}, linear, []float64{5.0 / 3, 5.0 / 3, 5.0 / 3, 5}},
+		{"linear falls back to zero at the worst", []float64{0, 10, 10, 10}, linear, []float64{0, 10, 10, 10}},
+		{"linear pressure 1 is uniform", []float64{-3, 1, 7},
+			&proto.FitnessScaling{Method: proto.ScalingMethod_SCALING_LINEAR, LinearPressure: 1}, []float64{14.0 / 3, 14.0 / 3, 14.0 / 3}},
+		{"sigma truncation", []float64{0, 10}, sigma, []float64{5, 15}},
+		{"sigma truncation cuts the tail", []float64{0, 100, 100, 100, 100, 100, 100, 100, 100, 100},
+			&proto.FitnessScaling{Method: proto.ScalingMethod_SCALING_SIGMA_TRUNCATION, SigmaC: 1}, nil},
+		{"boltzmann", []float64{0, 10}, boltzmann, []float64{math.Exp(-2), 1}},
+		{"boltzmann with huge spread", []float64{-1e12, -2e6, -1e6}, boltzmann, nil},
+		{"all scores equal", []float64{-5e6, -5e6, -5e6}, linear, []float64{1, 1, 1}},
+		{"excluded scores get zero", []float64{inf, -2, -1, inf}, boltzmann, nil},
+		{"only one score left", []float64{inf, -2, inf}, sigma, []float64{0, 1, 0}},
+		{"all excluded", []float64{inf, inf}, linear, []float64{0, 0}},
+		{"no scaling is uniform", []float64{-2, -1, 0}, nil, []float64{1, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScaledWeights(tt.scores, tt.scaling)
+			if len(got) != len(tt.scores) {
+				t.Fatalf("got %d weights, want %d", len(got), len(tt.scores))
+			}
+			for i, w := range got {
+				if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+					t.Errorf("weight %d = %v, want finite and non-negative", i, w)
+				}
+				if math.IsInf(tt.scores[i], -1) && w != 0 {
+					t.Errorf("weight %d = %v for excluded score, want 0", i, w)
+				}
+				for j := range got {
+					if tt.scores[i] > tt.scores[j] && got[i] < got[j] {
+						t.Errorf("weight %d = %v is less than weight %d = %v for a lower score", i, w, j, got[j])
+					}
+				}
+			}
+			for i := range tt.want {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("weights = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRouletteWeights(t *testing.T) {
+	linear := &proto.FitnessScaling{Method: proto.ScalingMethod_SCALING_LINEAR}
+
+	tests := []struct {
+		name     string
+		costs    []float64
+		scaling  *proto.FitnessScaling
+		wantZero []bool
+	}{
+		{"infeasible and worst feasible get zero", []float64{1e6, InfeasibleCost, 1.5e6, 2e6}, linear, []bool{false, true, false, true}},
+		{"all infeasible stay selectable", []float64{InfeasibleCost, InfeasibleCost}, linear, []bool{false, false}},
+		{"all costs equal", []float64{3e6, 3e6, 3e6}, linear, []bool{false, false, false}},
+		{"no scaling", []float64{1e6, InfeasibleCost}, nil, []bool{false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RouletteWeights(tt.costs, tt.scaling)
+			total := 0.0
+			for i, w := range got {
+				if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+					t.Errorf("weight %d = %v, want finite and non-negative", i, w)
+				}
+				if (w == 0) != tt.wantZero[i] {
+					t.Errorf("weight %d = %v, want zero: %v", i, w, tt.wantZero[i])
+				}
+				total += w
+			}
+			if total <= 0 {
+				t.Errorf("weights = %v, want a positive total", got)
+			}
+		})
+	}
+}
+
+func TestRankWeights(t *testing.T) {
+	if got, want := RankWeights(4, nil), []float64{4, 3, 2, 1}; !equal(got, want) {
+		t.Errorf("RankWeights(4, nil) = %v, want %v", got, want)
+	}
+	got := RankWeights(5, &proto.FitnessScaling{Method: proto.ScalingMethod_SCALING_BOLTZMANN})
+	for j := 1; j < len(got); j++ {
+		if got[j] > got[j-1] {
+			t.Errorf("RankWeights = %v, want non-increasing by rank", got)
+		}
+	}
+}
+
+func equal(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+package logic
+
+import (
+	"math"
+	"sort"
+
+	"noytech-ga-optimizer/internal/models"
+)
+
+const (
+	OversizedPenalty        = 50000.0 // Груз не помещается ни в одно ТС
+	FleetShortagePenalty    = 30000.0 // Рейс сверх доступного на день количества ТС
+	UnderutilizationPenalty = 10000.0 // За каждую долю недозагрузки ниже MinUtilization
+	MinUtilization          = 0.6
+)
+
+// VehicleLoad — один рейс ТС с назначенными на него грузами.
+type VehicleLoad struct {
+	Vehicle    models.Vehicle
+	Shipments  []models.Shipment
+	WeightTons float64
+	VolumeM3   float64
+	Pallets    int
+	Oversized  bool // Единственный груз рейса больше самого крупного ТС
+	Shortage   bool // На рейс не хватило свободного ТС подходящего класса
+}
+
+func (v VehicleLoad) Utilization() float64 {
+	u := math.Max(v.WeightTons/v.Vehicle.CapacityTons, v.VolumeM3/v.Vehicle.CapacityM3)
+	if v.Vehicle.CapacityPallets > 0 {
+		u = math.Max(u, float64(v.Pallets)/float64(v.Vehicle.CapacityPallets))
+	}
+	return u
+}
+
+func (v VehicleLoad) ShipmentIDs() []string {
+	ids := make([]string, len(v.Shipments))
+	for i, s := range v.Shipments {
+		ids[i] = s.ID
+	}
+	return ids
+}
+
+// PackShipments раскладывает поток грузов терминала по ТС из fleet (по возрастанию вместимости, см. SortFleet).
+// Если весь поток помещается в одно ТС, берётся минимальное подходящее. Иначе грузы раскладываются
+// методом first-fit decreasing по самым крупным ТС, после чего каждый рейс уменьшается до минимального
+// подходящего ТС. Груз крупнее самого большого ТС едет отдельным рейсом с пометкой Oversized.
+// Вместимость учитывается в тоннах, м³ и паллетах (см. fits).
+func PackShipments(shipments []models.Shipment, fleet []models.Vehicle) []VehicleLoad {
+	if len(shipments) == 0 || len(fleet) == 0 {
+		return nil
+	}
+
+	largest := fleet[len(fleet)-1]
+
+	total := VehicleLoad{Shipments: shipments}
+	for _, s := range shipments {
+		total.WeightTons += s.WeightKg / 1000.0
+		total.VolumeM3 += s.VolumeM3
+		total.Pallets += s.Pallets
+	}
+	if fits(largest, total.WeightTons, total.VolumeM3, total.Pallets) {
+		total.Vehicle = smallestFitting(total.WeightTons, total.VolumeM3, total.Pallets, fleet)
+		return []VehicleLoad{total}
+	}
+
+	// 1. Сортируем грузы по убыванию доли вместимости крупнейшего ТС
+	order := make([]int, len(shipments))
+	for i := range order {
+		order[i] = i
+	}
+	size := func(s models.Shipment) float64 {
+		size := math.Max(s.WeightKg/1000.0/largest.CapacityTons, s.VolumeM3/largest.CapacityM3)
+		if largest.CapacityPallets > 0 {
+			size = math.Max(size, float64(s.Pallets)/float64(largest.CapacityPallets))
+		}
+		return size
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return size(shipments[order[a]]) > size(shipments[order[b]])
+	})
+
+	// 2. First-fit decreasing по крупнейшим ТС
+	type bin struct {
+		indices    []int
+		weightTons float64
+		volumeM3   float64
+		pallets    int
+		oversized  bool
+	}
+	bins := make([]*bin, 0)
+	for _, i := range order {
+		s := shipments[i]
+		w, v, p := s.WeightKg/1000.0, s.VolumeM3, s.Pallets
+
+		if !fits(largest, w, v, p) {
+			bins = append(bins, &bin{indices: []int{i}, weightTons: w, volumeM3: v, pallets: p, oversized: true})
+			continue
+		}
+
+		placed := false
+		for _, b := range bins {
+			if !b.oversized && fits(largest, b.weightTons+w, b.volumeM3+v, b.pallets+p) {
+				b.indices = append(b.indices, i)
+				b.weightTons += w
+				b.volumeM3 += v
+				b.pallets += p
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			bins = append(bins, &bin{indices: []int{i}, weightTons: w, volumeM3: v, pallets: p})
+		}
+	}
+
+	// 3. Уменьшаем каждый рейс до минимального подходящего ТС
+	loads := make([]VehicleLoad, len(bins))
+	for k, b := range bins {
+		sort.Ints(b.indices)
+		load := VehicleLoad{
+			Shipments:  make([]models.Shipment, len(b.indices)),
+			WeightTons: b.weightTons,
+			VolumeM3:   b.volumeM3,
+			Pallets:    b.pallets,
+			Oversized:  b.oversized,
+		}
+		for n, i := range b.indices {
+			load.Shipments[n] = shipments[i]
+		}
+		if b.oversized {
+			load.Vehicle = largest
+		} else {
+			load.Vehicle = smallestFitting(b.weightTons, b.volumeM3, b.pallets, fleet)
+		}
+		loads[k] = load
+	}
+	return loads
+}
+
+// AllocateVehicles раскладывает поток терминала по ТС из vehicles (по возрастанию вместимости)
+// с учётом свободных машин в pool. Если для рейса нет свободного ТС подходящего класса, рейс
+// раскладывается на несколько меньших свободных ТС; если и это невозможно, рейс выполняется
+// минимальным подходящим ТС сверх лимита и помечается Shortage.
+func AllocateVehicles(shipments []models.Shipment, vehicles []models.Vehicle, pool *FleetPool) []VehicleLoad {
+	if len(shipments) == 0 || len(vehicles) == 0 {
+		return nil
+	}
+
+	available := pool.Available(vehicles)
+	if len(available) == 0 {
+		loads := PackShipments(shipments, vehicles)
+		for i := range loads {
+			loads[i].Shortage = !pool.Take(loads[i].Vehicle.ID)
+		}
+		return loads
+	}
+
+	result := make([]VehicleLoad, 0)
+	for _, load := range PackShipments(shipments, available) {
+		// 1. Свободное ТС подходящего класса (могло закончиться на предыдущих рейсах)
+		if v, ok := takeSmallestFitting(load.WeightTons, load.VolumeM3, load.Pallets, vehicles, pool); ok {
+			load.Vehicle = v
+			load.Oversized = false
+			result = append(result, load)
+			continue
+		}
+
+		// 2. Несколько меньших свободных ТС
+		if len(load.Shipments) > 1 {
+			result = append(result, AllocateVehicles(load.Shipments, vehicles, pool)...)
+			continue
+		}
+
+		// 3. Рейс сверх лимита
+		load.Vehicle = smallestFitting(load.WeightTons, load.VolumeM3, load.Pallets, vehicles)
+		load.Oversized = !fits(load.Vehicle, load.WeightTons, load.VolumeM3, load.Pallets)
+		load.Shortage = !pool.Take(load.Vehicle.ID)
+		result = append(result, load)
+	}
+	return result
+}
+
+// LoadPenalty — штрафы за грузы крупнее любого ТС, за рейсы сверх доступного автопарка и за недозагрузку.
+func LoadPenalty(loads []VehicleLoad) float64 {
+	penalty := 0.0
+	for _, l := range loads {
+		if l.Shortage {
+			penalty += FleetShortagePenalty
+		}
+		if l.Oversized {
+			penalty += OversizedPenalty
+			continue
+		}
+		if u := l.Utilization(); u < MinUtilization {
+			penalty += UnderutilizationPenalty * (MinUtilization - u)
+		}
+	}
+	return penalty
+}
+
+// fits сообщает, помещается ли груз в ТС. Вместимость 0 паллет у ТС — без ограничения по паллетам;
+// грузы без указанных паллет (0) его не занимают.
+func fits(v models.Vehicle, weightTons, volumeM3 float64, pallets int) bool {
+	return weightTons <= v.CapacityTons && volumeM3 <= v.CapacityM3 && (v.CapacityPallets == 0 || pallets <= v.CapacityPallets)
+}
+
+func takeSmallestFitting(weightTons, volumeM3 float64, pallets int, vehicles []models.Vehicle, pool *FleetPool) (models.Vehicle, bool) {
+	for _, v := range vehicles {
+		if fits(v, weightTons, volumeM3, pallets) && pool.free(v.ID) {
+			pool.Take(v.ID)
+			return v, true
+		}
+	}
+	return models.Vehicle{}, false
+}
+
+func smallestFitting(weightTons, volumeM3 float64, pallets int, fleet []models.Vehicle) models.Vehicle {
+	for _, v := range fleet {
+		if fits(v, weightTons, volumeM3, pallets) {
+			return v
+		}
+	}
+	return fleet[len(fleet)-1]
+}
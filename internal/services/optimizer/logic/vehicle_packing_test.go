@@ -0,0 +1,136 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+
+	"noytech-ga-optimizer/internal/models"
+)
+
+var testFleet = []models.Vehicle{
+	{ID: "1.5t", CapacityTons: 1.5, CapacityM3: 10, CapacityPallets: 4},
+	{ID: "3t", CapacityTons: 3, CapacityM3: 20, CapacityPallets: 8},
+	{ID: "5t", CapacityTons: 5, CapacityM3: 36, CapacityPallets: 15},
+	{ID: "10t", CapacityTons: 10, CapacityM3: 45, CapacityPallets: 18},
+	{ID: "20t", CapacityTons: 20, CapacityM3: 86, CapacityPallets: 33},
+}
+
+func shipment(id string, weightKg, volumeM3 float64, pallets int) models.Shipment {
+	return models.Shipment{ID: id, WeightKg: weightKg, VolumeM3: volumeM3, Pallets: pallets}
+}
+
+func TestPackShipments(t *testing.T) {
+	tests := []struct {
+		name      string
+		shipments []models.Shipment
+		want      []string // Классы ТС рейсов по порядку
+		oversized []bool
+	}{
+		{"whole flow fits the smallest fitting vehicle",
+			[]models.Shipment{shipment("a", 1000, 5, 0), shipment("b", 1000, 5, 0)},
+			[]string{"3t"}, []bool{false}},
+		{"shipment larger than every vehicle goes alone",
+			[]models.Shipment{shipment("small", 500, 2, 0), shipment("huge", 25000, 10, 0)},
+			[]string{"20t", "1.5t"}, []bool{true, false}},
+		{"limited by weight",
+			[]models.Shipment{shipment("a", 6000, 1, 0), shipment("b", 6000, 1, 0), shipment("c", 6000, 1, 0), shipment("d", 6000, 1, 0)},
+			[]string{"20t", "10t"}, []bool{false, false}},
+		{"limited by volume",
+			[]models.Shipment{shipment("a", 100, 40, 0), shipment("b", 100, 40, 0), shipment("c", 100, 40, 0)},
+			[]string{"20t", "10t"}, []bool{false, false}},
+		{"limited by pallets",
+			[]models.Shipment{shipment("a", 100, 1, 12), shipment("b", 100, 1, 12), shipment("c", 100, 1, 12)},
+			[]string{"20t", "5t"}, []bool{false, false}},
+		{"trips split across vehicle classes",
+			[]models.Shipment{shipment("a", 1000, 5, 0), shipment("b", 15000, 70, 0), shipment("c", 4000, 14, 0)},
+			[]string{"20t", "1.5t"}, []bool{false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loads := PackShipments(tt.shipments, testFleet)
+
+			got := make([]string, len(loads))
+			oversized := make([]bool, len(loads))
+			var ids []string
+			for i, l := range loads {
+				got[i] = l.Vehicle.ID
+				oversized[i] = l.Oversized
+				ids = append(ids, l.ShipmentIDs()...)
+				if !l.Oversized && !fits(l.Vehicle, l.WeightTons, l.VolumeM3, l.Pallets) {
+					t.Errorf("load %d (%.1f t, %.1f m3, %d pallets) exceeds %s", i, l.WeightTons, l.VolumeM3, l.Pallets, l.Vehicle.ID)
+				}
+			}
+			if !slices.Equal(got, tt.want) || !slices.Equal(oversized, tt.oversized) {
+				t.Errorf("loads = %v oversized %v, want %v oversized %v", got, oversized, tt.want, tt.oversized)
+			}
+
+			// Каждый груз попадает ровно в один рейс
+			slices.Sort(ids)
+			var want []string
+			for _, s := range tt.shipments {
+				want = append(want, s.ID)
+			}
+			slices.Sort(want)
+			if !slices.Equal(ids, want) {
+				t.Errorf("packed shipments = %v, want %v", ids, want)
+			}
+		})
+	}
+}
+
+func TestPackShipmentsEmpty(t *testing.T) {
+	if loads := PackShipments(nil, testFleet); loads != nil {
+		t.Errorf("PackShipments(nil) = %v, want nil", loads)
+	}
+}
+
+func TestAllocateVehicles(t *testing.T) {
+	heavy := make([]models.Shipment, 8)
+	for i := range heavy {
+		heavy[i] = shipment(string(rune('a'+i)), 3000, 1, 0)
+	}
+
+	tests := []struct {
+		name     string
+		limits   map[string]int
+		want     []string
+		shortage []bool
+	}{
+		{"no limits packs like PackShipments", nil,
+			[]string{"20t", "10t"}, []bool{false, false}},
+		{"exhausted class falls back to the next free class", map[string]int{"20t": 0},
+			[]string{"10t", "10t", "10t"}, []bool{false, false, false}},
+		{"trip is split across smaller free vehicles", map[string]int{"20t": 0, "10t": 1},
+			[]string{"10t", "3t", "3t", "3t", "3t", "3t"}, []bool{false, false, false, false, false, false}},
+		{"trip over the limit when no vehicle is free", map[string]int{"1.5t": 0, "3t": 0, "5t": 0, "10t": 0, "20t": 0},
+			[]string{"20t", "10t"}, []bool{true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := Fleet{Vehicles: testFleet, Limits: tt.limits}.NewPool()
+			loads := AllocateVehicles(heavy, testFleet, pool)
+
+			got := make([]string, len(loads))
+			shortage := make([]bool, len(loads))
+			used := make(map[string]int)
+			for i, l := range loads {
+				got[i] = l.Vehicle.ID
+				shortage[i] = l.Shortage
+				used[l.Vehicle.ID]++
+				if !fits(l.Vehicle, l.WeightTons, l.VolumeM3, l.Pallets) {
+					t.Errorf("load %d (%.1f t) exceeds %s", i, l.WeightTons, l.Vehicle.ID)
+				}
+			}
+			if !slices.Equal(got, tt.want) || !slices.Equal(shortage, tt.shortage) {
+				t.Errorf("loads = %v shortage %v, want %v shortage %v", got, shortage, tt.want, tt.shortage)
+			}
+			if !slices.Contains(tt.shortage, true) {
+				for id, limit := range tt.limits {
+					if used[id] > limit {
+						t.Errorf("used %d vehicles of class %s, only %d available", used[id], id, limit)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package optimizer
+
+import (
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/ga_level1"
+	"noytech-ga-optimizer/internal/services/optimizer/ga_level2"
+)
+
+// objectiveValues пересчитывает цели многокритериального режима по итоговому плану дня:
+// 2-й уровень мог изменить назначение грузов и стоимость решения, найденного на 1-м уровне.
+func objectiveValues(
+	objectives []proto.Objective,
+	plan *ga_level2.Individual,
+	shipments []models.Shipment,
+	distances map[string]map[string]int,
+) []*proto.ObjectiveValue {
+	destinations := make(map[string]string, len(shipments))
+	for _, s := range shipments {
+		destinations[s.ID] = s.DestinationCity
+	}
+
+	totalDistance, assigned := 0, 0
+	for _, r := range plan.Routes {
+		for _, id := range r.ShipmentIDs {
+			if d, ok := distances[r.ToTerminal][destinations[id]]; ok {
+				totalDistance += d
+				assigned++
+			}
+		}
+	}
+
+	summary := &ga_level1.Individual{
+		Fitness:         plan.Fitness,
+		ActiveTerminals: plan.ActiveTerminals,
+	}
+	if assigned > 0 {
+		summary.AvgDistanceKm = float64(totalDistance) / float64(assigned)
+	}
+
+	values := make([]*proto.ObjectiveValue, len(objectives))
+	for i, o := range objectives {
+		values[i] = &proto.ObjectiveValue{
+			Objective: o,
+			Value:     ga_level1.ObjectiveValue(summary, o),
+		}
+	}
+	return values
+}
+
+// setParetoRanks проставляет pareto_rank решениям дня по значениям целей их итоговых планов.
+func setParetoRanks(results []*proto.OptimizationResult) {
+	objectives := make([][]float64, len(results))
+	for i, r := range results {
+		objectives[i] = make([]float64, len(r.Objectives))
+		for k, v := range r.Objectives {
+			objectives[i][k] = v.Value
+		}
+	}
+	for i, rank := range ga_level1.ParetoRanks(objectives) {
+		results[i].ParetoRank = int32(rank)
+	}
+}
+
+// baselineComparison сравнивает лучшие наборы терминалов ГА и эталонного алгоритма по функции пригодности 1-го уровня.
+func baselineComparison(algorithm proto.Algorithm, baseline, ga *ga_level1.Individual) *proto.BaselineComparison {
+	comparison := &proto.BaselineComparison{
+		Algorithm:       algorithm,
+		BaselineFitness: baseline.Fitness,
+		GaFitness:       ga.Fitness,
+	}
+	if baseline.Fitness != 0 {
+		comparison.GapPercent = (ga.Fitness - baseline.Fitness) / baseline.Fitness * 100
+	}
+	return comparison
+}
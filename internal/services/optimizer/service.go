@@ -2,7 +2,13 @@ package optimizer
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"noytech-ga-optimizer/api/proto"
 	"noytech-ga-optimizer/internal/models"
@@ -10,22 +16,81 @@ import (
 	"noytech-ga-optimizer/internal/services/optimizer/ga_level2"
 	"noytech-ga-optimizer/internal/services/optimizer/logic"
 	storage "noytech-ga-optimizer/internal/storages"
+	"noytech-ga-optimizer/internal/validation"
 	"noytech-ga-optimizer/pkg/errors"
 )
 
+// Config — параметры выполнения оптимизации.
+type Config struct {
+	Workers          int // Горутин для параллельной оценки fitness в одном прогоне ГА
+	FitnessCacheSize int // Масок в LRU-кэше fitness 1-го уровня на прогон (0 — без кэша)
+}
+
 type Service struct {
 	storage storage.Storage
 	logger  *slog.Logger
+	cfg     Config
 }
 
-func New(s storage.Storage, l *slog.Logger) *Service {
+func New(s storage.Storage, cfg Config, l *slog.Logger) *Service {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
 	return &Service{
 		storage: s,
 		logger:  l,
+		cfg:     cfg,
+	}
+}
+
+// Progress — ход оптимизации: текущий день отгрузки и состояние ГА 1-го уровня.
+type Progress struct {
+	DeliveryDay string
+	DayIndex    int
+	DaysTotal   int
+	ga_level1.Progress
+}
+
+// ProgressFunc получает прогресс оптимизации. Может быть nil.
+type ProgressFunc func(Progress)
+
+func (p Progress) ToProto() *proto.GenerationProgress {
+	return &proto.GenerationProgress{
+		DeliveryDay:     p.DeliveryDay,
+		DayIndex:        int32(p.DayIndex),
+		DaysTotal:       int32(p.DaysTotal),
+		Generation:      int32(p.Generation),
+		NumGenerations:  int32(p.NumGenerations),
+		BestFitness:     p.BestFitness,
+		MeanFitness:     p.MeanFitness,
+		WorstFitness:    p.WorstFitness,
+		NoImprovement:   int32(p.NoImprovement),
+		ActiveTerminals: p.ActiveTerminals,
+		MedianFitness:   p.MedianFitness,
+		Diversity:       p.Diversity,
+		DistinctGenomes: int32(p.DistinctGenomes),
+	}
+}
+
+// generationStats — запись хода ГА 1-го уровня для OptimizationResult.history.
+func generationStats(p ga_level1.Progress) *proto.GenerationStats {
+	return &proto.GenerationStats{
+		Generation:      int32(p.Generation),
+		BestFitness:     p.BestFitness,
+		MeanFitness:     p.MeanFitness,
+		MedianFitness:   p.MedianFitness,
+		WorstFitness:    p.WorstFitness,
+		Diversity:       p.Diversity,
+		DistinctGenomes: int32(p.DistinctGenomes),
 	}
 }
 
-func (s *Service) Optimize(ctx context.Context, req *proto.OptimizeRequest) (*proto.OptimizationResult, error) {
+func (s *Service) Optimize(ctx context.Context, req *proto.OptimizeRequest) (*proto.OptimizeResponse, error) {
+	return s.OptimizeWithProgress(ctx, req, nil)
+}
+
+func (s *Service) OptimizeWithProgress(ctx context.Context, req *proto.OptimizeRequest, onProgress ProgressFunc) (*proto.OptimizeResponse, error) {
 	logger := s.logger.With(
 		slog.String("method", "Optimize"),
 		slog.String("direction", req.Direction),
@@ -33,6 +98,7 @@ func (s *Service) Optimize(ctx context.Context, req *proto.OptimizeRequest) (*pr
 	)
 
 	logger.Info("Starting optimization request")
+	startedAt := time.Now()
 
 	// 1. Загрузка всех данных из БД
 	shipments, err := s.storage.GetAllShipments(ctx)
@@ -65,6 +131,24 @@ func (s *Service) Optimize(ctx context.Context, req *proto.OptimizeRequest) (*pr
 		return nil, errors.NewErrOptimizationFailed("failed to load intra-city rates: %v", err)
 	}
 
+	vehicles, err := s.storage.GetAllVehicles(ctx)
+	if err != nil {
+		logger.Error("Failed to load fleet", "error", err)
+		return nil, errors.NewErrOptimizationFailed("failed to load fleet: %v", err)
+	}
+	if len(vehicles) == 0 {
+		return nil, errors.NewErrOptimizationFailed("fleet catalog is empty")
+	}
+	vehicles = logic.SortFleet(vehicles)
+
+	availability, err := s.storage.GetFleetAvailability(ctx)
+	if err != nil {
+		logger.Error("Failed to load fleet availability", "error", err)
+		return nil, errors.NewErrOptimizationFailed("failed to load fleet availability: %v", err)
+	}
+
+	fingerprint := datasetFingerprint(shipments, terminals, distances, interCityRates, intraCityRates, vehicles, availability)
+
 	// 2. Фильтрация терминалов по направлению (если указано)
 	filteredTerminals := terminals
 	if req.Direction != "" {
@@ -79,6 +163,31 @@ func (s *Service) Optimize(ctx context.Context, req *proto.OptimizeRequest) (*pr
 		}
 	}
 
+	if req.Algorithm == proto.Algorithm_ALGORITHM_EXHAUSTIVE && len(filteredTerminals) > ga_level1.MaxExhaustiveTerminals {
+		return nil, errors.NewErrInvalidArgumentWithDetails([]errors.ErrorDetail{{
+			Field:   "algorithm",
+			Message: fmt.Sprintf("exhaustive search supports at most %d terminals, got %d", ga_level1.MaxExhaustiveTerminals, len(filteredTerminals)),
+		}})
+	}
+
+	if err := validation.ValidateTerminalConstraints(req, filteredTerminals); err != nil {
+		logger.Warn("Terminal constraints rejected", "error", err)
+		return nil, err
+	}
+	constraints := ga_level1.NewConstraints(
+		filteredTerminals,
+		req.MustOpen,
+		req.MustClose,
+		int(req.MinActiveTerminals),
+		int(req.MaxActiveTerminals),
+	)
+
+	warmStart, err := s.loadWarmStart(ctx, req)
+	if err != nil {
+		logger.Warn("Warm start rejected", "error", err)
+		return nil, err
+	}
+
 	// 3. Преобразуем distances
 	distancesMap := make(map[string]map[string]int)
 	for _, d := range distances {
@@ -95,76 +204,268 @@ func (s *Service) Optimize(ctx context.Context, req *proto.OptimizeRequest) (*pr
 		return nil, errors.NewErrOptimizationFailed("grouping failed: %v", err)
 	}
 
-	// 5. Будем хранить лучший результат среди дней
-	var bestResult *proto.OptimizationResult
-	var bestCost float64 = 1e18
+	// 5. Один ГСЧ на весь прогон: оба уровня ГА и все дни отгрузки
+	seed := newSeed()
+	if req.GaSettingsLevel_1.Seed != nil {
+		seed = *req.GaSettingsLevel_1.Seed
+	}
+	rng := rand.New(rand.NewSource(seed))
+	logger = logger.With(slog.Int64("seed", seed))
+
+	multiObjective := len(req.GaSettingsLevel_1.Objectives) > 0
+	baseline := req.Algorithm != proto.Algorithm_ALGORITHM_UNSPECIFIED && req.Algorithm != proto.Algorithm_ALGORITHM_GA
 
-	// Запуск оптимизации для каждого дня отгрузки
-	for deliveryDay, dayShipments := range groupedShipments {
+	// 6. Результаты по каждому дню отгрузки и суммарная стоимость недели
+	results := make([]*proto.OptimizationResult, 0, len(req.DeliveryDays))
+	weeklyCost := &proto.CostBreakdown{}
+
+	// Запуск оптимизации для каждого дня отгрузки (в порядке из запроса)
+	for dayIndex, deliveryDay := range req.DeliveryDays {
+		dayShipments := groupedShipments[deliveryDay]
 		logger.Info("Optimizing for delivery day", "day", deliveryDay, "shipment_count", len(dayShipments))
 
-		// Уровень 1: выбор терминалов
-		level1Result, err := ga_level1.RunGA(
-			req.GaSettingsLevel_1,
-			filteredTerminals,
-			dayShipments,
-			interCityRates,
-			intraCityRates,
-			distancesMap,
-		)
+		fleet := logic.Fleet{Vehicles: vehicles, Limits: fleetLimits(availability, deliveryDay)}
+
+		// Ход ГА по поколениям собирается из того же прогресса, что получают клиенты. Начальная популяция
+		// (поколение 0) попадает только в history: события прогресса нумеруются с 1, и последнее из них
+		// при остановке по num_generations имеет generation == num_generations
+		var history []*proto.GenerationStats
+		var dayProgress ga_level1.ProgressFunc
+		if onProgress != nil || req.IncludeHistory {
+			dayProgress = func(p ga_level1.Progress) {
+				if req.IncludeHistory {
+					history = append(history, generationStats(p))
+				}
+				if onProgress != nil && p.Generation > 0 {
+					onProgress(Progress{
+						DeliveryDay: deliveryDay,
+						DayIndex:    dayIndex,
+						DaysTotal:   len(req.DeliveryDays),
+						Progress:    p,
+					})
+				}
+			}
+		}
+
+		// Уровень 1: выбор терминалов — одно лучшее решение или фронт Парето в многокритериальном режиме.
+		// Кэш fitness действителен только для грузов этого дня
+		cache := ga_level1.NewFitnessCache(s.cfg.FitnessCacheSize)
+		seeds := warmStart.masks(deliveryDay, filteredTerminals)
+		if warmStart != nil && len(seeds) == 0 {
+			logger.Warn("No warm start terminal sets for day, starting from random population", "day", deliveryDay)
+		}
+		level1Start := time.Now()
+		var level1 *ga_level1.Result
+		if multiObjective {
+			level1, err = ga_level1.RunNSGA2(
+				ctx,
+				req.GaSettingsLevel_1,
+				rng,
+				s.cfg.Workers,
+				cache,
+				filteredTerminals,
+				constraints,
+				seeds,
+				dayShipments,
+				interCityRates,
+				intraCityRates,
+				distancesMap,
+				fleet,
+				dayProgress,
+			)
+		} else {
+			level1, err = ga_level1.RunGA(
+				ctx,
+				req.GaSettingsLevel_1,
+				rng,
+				s.cfg.Workers,
+				cache,
+				filteredTerminals,
+				constraints,
+				seeds,
+				dayShipments,
+				interCityRates,
+				intraCityRates,
+				distancesMap,
+				fleet,
+				dayProgress,
+			)
+		}
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				logger.Warn("Optimization cancelled", "day", deliveryDay, "error", ctxErr)
+				return nil, ctxErr
+			}
 			logger.Error("Level 1 GA failed", "day", deliveryDay, "error", err)
 			return nil, errors.NewErrOptimizationFailed("level 1 GA failed: %v", err)
 		}
+		logger.Info("Level 1 GA stopped", "day", deliveryDay, "reason", level1.StopReason, "generation", level1.StopGeneration)
+		level1Elapsed := time.Since(level1Start)
+		if level1.TimeLimited {
+			logger.Warn("Level 1 GA stopped by time budget", "day", deliveryDay, "max_duration_ms", req.GaSettingsLevel_1.MaxDurationMs)
+		}
 
-		var activeTerminals []models.Terminal
-		for _, city := range level1Result.ActiveTerminals {
-			for _, t := range filteredTerminals {
-				if t.City == city {
-					activeTerminals = append(activeTerminals, t)
-					break
+		level1Results := []*ga_level1.Individual{level1.Best}
+		if multiObjective {
+			level1Results = level1.Front
+		}
+
+		// Эталонный алгоритм: его набор терминалов идёт в результат, ГА — только для сравнения
+		var comparison *proto.BaselineComparison
+		if baseline {
+			baselineResult, err := ga_level1.RunBaseline(
+				ctx,
+				req.Algorithm,
+				s.cfg.Workers,
+				filteredTerminals,
+				constraints,
+				dayShipments,
+				interCityRates,
+				intraCityRates,
+				distancesMap,
+				fleet,
+			)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					logger.Warn("Optimization cancelled", "day", deliveryDay, "error", ctxErr)
+					return nil, ctxErr
 				}
+				logger.Error("Baseline solver failed", "day", deliveryDay, "algorithm", req.Algorithm, "error", err)
+				return nil, errors.NewErrOptimizationFailed("baseline solver failed: %v", err)
 			}
-		}
 
-		// Уровень 2: расчёт стоимости для фиксированного набора терминалов
-		level2Result, err := ga_level2.RunGALevel2(
-			activeTerminals,
-			dayShipments,
-			interCityRates,
-			intraCityRates,
-			distancesMap,
-		)
-		if err != nil {
-			logger.Error("Level 2 GA failed", "day", deliveryDay, "error", err)
-			return nil, errors.NewErrOptimizationFailed("level 2 GA failed: %v", err)
+			comparison = baselineComparison(req.Algorithm, baselineResult, level1.Best)
+			logger.Info("Baseline solver finished", "day", deliveryDay, "algorithm", req.Algorithm, "gap_percent", comparison.GapPercent)
+			level1Results = []*ga_level1.Individual{baselineResult}
 		}
 
-		protoResult := s.convertToProto(level2Result, 0)
+		stats := cache.Stats()
+		logger.Info("Level 1 GA finished", "day", deliveryDay, "solutions", len(level1Results), "cache_hits", stats.Hits, "cache_misses", stats.Misses)
+
+		// В недельную стоимость входит самое дешёвое решение дня
+		var cheapest *proto.OptimizationResult
+		var dayResults []*proto.OptimizationResult
+		for _, level1Result := range level1Results {
+			var activeTerminals []models.Terminal
+			for _, city := range level1Result.ActiveTerminals {
+				for _, t := range filteredTerminals {
+					if t.City == city {
+						activeTerminals = append(activeTerminals, t)
+						break
+					}
+				}
+			}
+
+			// Уровень 2: назначение грузов и выбор ТС для фиксированного набора терминалов
+			level2, err := ga_level2.RunGALevel2(
+				ctx,
+				req.GaSettingsLevel_2,
+				rng,
+				s.cfg.Workers,
+				activeTerminals,
+				dayShipments,
+				interCityRates,
+				intraCityRates,
+				distancesMap,
+				fleet,
+			)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					logger.Warn("Optimization cancelled", "day", deliveryDay, "error", ctxErr)
+					return nil, ctxErr
+				}
+				logger.Error("Level 2 GA failed", "day", deliveryDay, "error", err)
+				return nil, errors.NewErrOptimizationFailed("level 2 GA failed: %v", err)
+			}
+			if level2.TimeLimited {
+				logger.Warn("Level 2 GA stopped by time budget", "day", deliveryDay, "max_duration_ms", req.GaSettingsLevel_2.GetMaxDurationMs())
+			}
+			level2Result := level2.Best
+
+			protoResult := s.convertToProto(level2Result, int32(level1Result.Generation))
+			protoResult.DeliveryDay = deliveryDay
+			protoResult.FleetUsage = fleetUsage(level2Result.Routes, fleet)
+			for _, u := range protoResult.FleetUsage {
+				if u.Shortage > 0 {
+					logger.Warn("Fleet availability exceeded", "day", deliveryDay, "vehicle_id", u.VehicleId, "shortage", u.Shortage)
+				}
+			}
+			protoResult.Stats = &proto.RunStats{
+				FitnessCacheHits:           stats.Hits,
+				FitnessCacheMisses:         stats.Misses,
+				LocalSearchEvaluations:     level1.LocalSearch.Evaluations,
+				LocalSearchImproved:        int32(level1.LocalSearch.Improved),
+				LocalSearchImprovement:     level1.LocalSearch.Improvement,
+				LocalSearchBestImprovement: level1.LocalSearch.BestImprovement,
+				Evaluations:                level1.Evaluations,
+				ElapsedMs:                  level1Elapsed.Milliseconds(),
+			}
+			protoResult.History = history
+			protoResult.TimeLimited = level1.TimeLimited || level2.TimeLimited
+			protoResult.StopReason = level1.StopReason
+			protoResult.StopGeneration = int32(level1.StopGeneration)
+			protoResult.Baseline = comparison
+			if multiObjective {
+				protoResult.Objectives = objectiveValues(req.GaSettingsLevel_1.Objectives, level2Result, dayShipments, distancesMap)
+			}
+			results = append(results, protoResult)
+			dayResults = append(dayResults, protoResult)
+
+			if cheapest == nil || protoResult.Cost.TotalCost < cheapest.Cost.TotalCost {
+				cheapest = protoResult
+			}
+		}
 
-		if level2Result.Fitness < bestCost {
-			bestCost = level2Result.Fitness
-			bestResult = protoResult
+		// 2-й уровень меняет назначения и стоимость, поэтому ранги считаются заново по итоговым планам
+		if multiObjective {
+			setParetoRanks(dayResults)
 		}
+
+		weeklyCost.LinehaulCost += cheapest.Cost.LinehaulCost
+		weeklyCost.LastMileCost += cheapest.Cost.LastMileCost
+		weeklyCost.PenaltyCost += cheapest.Cost.PenaltyCost
+		weeklyCost.TotalCost += cheapest.Cost.TotalCost
 	}
 
-	if bestResult == nil {
+	if len(results) == 0 {
 		return nil, errors.NewErrOptimizationFailed("no valid result produced")
 	}
 
-	logger.Info("Optimization completed successfully", "best_total_cost", bestCost)
-	return bestResult, nil
+	logger.Info("Optimization completed successfully", "weekly_total_cost", weeklyCost.TotalCost)
+	resp := &proto.OptimizeResponse{
+		Success:    true,
+		Message:    "Optimization completed successfully",
+		Results:    results,
+		SolutionId: uuid.NewString(),
+		CreatedAt:  timestamppb.Now(),
+		WeeklyCost: weeklyCost,
+		Seed:       seed,
+	}
+
+	// 7. Сохраняем решение, чтобы к нему можно было вернуться по solution_id
+	if err := s.saveSolution(ctx, req, resp, fingerprint, startedAt); err != nil {
+		logger.Error("Failed to save solution", "solution_id", resp.SolutionId, "error", err)
+	}
+
+	return resp, nil
+}
+
+// maxGeneratedSeed — сгенерированный seed помещается в 53 бита и без потерь проходит через JSON (float64).
+const maxGeneratedSeed = 1 << 53
+
+func newSeed() int64 {
+	return time.Now().UnixNano() % maxGeneratedSeed
 }
 
 func (s *Service) convertToProto(level2 *ga_level2.Individual, generation int32) *proto.OptimizationResult {
 	routes := make([]*proto.Route, len(level2.Routes))
 	for i, r := range level2.Routes {
 		routes[i] = &proto.Route{
-			FromCity:      r.FromCity,
-			ToTerminal:    r.ToTerminal,
-			ShipmentIds:   r.ShipmentIDs,
-			Cost:          r.Cost,
-			TransportUsed: r.TransportUsed,
+			FromCity:    r.FromCity,
+			ToTerminal:  r.ToTerminal,
+			ShipmentIds: r.ShipmentIDs,
+			Cost:        r.Cost,
+			VehicleId:   r.VehicleID,
 		}
 	}
 
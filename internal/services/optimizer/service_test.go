@@ -0,0 +1,137 @@
+package optimizer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	protobuf "google.golang.org/protobuf/proto"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	storage "noytech-ga-optimizer/internal/storages"
+)
+
+// fakeStorage отдаёт небольшой набор данных; методы, не нужные оптимизации, не реализованы.
+type fakeStorage struct {
+	storage.Storage
+	shipments []models.Shipment
+	terminals []models.Terminal
+	distances []models.Distance
+	vehicles  []models.Vehicle
+	solutions []models.Solution
+}
+
+func newFakeStorage() *fakeStorage {
+	s := &fakeStorage{
+		vehicles: []models.Vehicle{
+			{ID: "3t", CapacityTons: 3, CapacityM3: 20},
+			{ID: "20t", CapacityTons: 20, CapacityM3: 86},
+		},
+	}
+	for k := range 5 {
+		city := fmt.Sprintf("T%d", k)
+		s.terminals = append(s.terminals, models.Terminal{City: city, DistanceFromMoscowKm: 100 + 80*k})
+		for c := range 6 {
+			s.distances = append(s.distances, models.Distance{FromCity: city, ToCity: fmt.Sprintf("c%d", c), Km: 20 + 30*((k+c)%7)})
+		}
+	}
+	monday := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+	for i := range 20 {
+		s.shipments = append(s.shipments, models.Shipment{
+			ID:              fmt.Sprintf("s%d", i),
+			WeightKg:        float64(400 + 250*(i%5)),
+			VolumeM3:        float64(1 + i%4),
+			DestinationCity: fmt.Sprintf("c%d", i%6),
+			Date:            monday.AddDate(0, 0, i%5),
+		})
+	}
+	return s
+}
+
+func (s *fakeStorage) GetAllShipments(context.Context) ([]models.Shipment, error) {
+	return s.shipments, nil
+}
+
+func (s *fakeStorage) GetAllTerminals(context.Context) ([]models.Terminal, error) {
+	return s.terminals, nil
+}
+
+func (s *fakeStorage) GetAllDistances(context.Context) ([]models.Distance, error) {
+	return s.distances, nil
+}
+
+func (s *fakeStorage) GetAllVehicles(context.Context) ([]models.Vehicle, error) {
+	return s.vehicles, nil
+}
+
+func (s *fakeStorage) GetAllInterCityRates(context.Context) ([]models.InterCityRate, error) {
+	return []models.InterCityRate{{VolumeM3: 100, WeightTons: 30, RatePerKm: 40}}, nil
+}
+
+func (s *fakeStorage) GetAllIntraCityRates(context.Context) ([]models.IntraCityRate, error) {
+	return []models.IntraCityRate{{VolumeM3: 100, WeightTons: 30, RateFixed: 1500}}, nil
+}
+
+func (s *fakeStorage) GetFleetAvailability(context.Context) ([]models.FleetAvailability, error) {
+	return nil, nil
+}
+
+func (s *fakeStorage) InsertSolution(_ context.Context, solution models.Solution) error {
+	s.solutions = append(s.solutions, solution)
+	return nil
+}
+
+func newTestService() *Service {
+	return New(newFakeStorage(), Config{Workers: 4}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func testRequest(seed *int64) *proto.OptimizeRequest {
+	return &proto.OptimizeRequest{
+		DeliveryDays: []string{"wed", "fri"},
+		GaSettingsLevel_1: &proto.GASettings{
+			NumGenerations:    10,
+			NumIndividuals:    10,
+			SelectionType:     proto.SelectionType_SELECTION_TOURNAMENT,
+			CrossoverType:     proto.CrossoverType_CROSSOVER_UNIFORM,
+			MutationType:      proto.MutationType_MUTATION_INVERSION,
+			StoppingCriterion: 10,
+			Seed:              seed,
+		},
+	}
+}
+
+func TestOptimizeReturnsGeneratedSeed(t *testing.T) {
+	svc := newTestService()
+
+	first, err := svc.Optimize(context.Background(), testRequest(nil))
+	if err != nil {
+		t.Fatalf("Optimize: %v", err)
+	}
+	if first.Seed < 0 || first.Seed >= maxGeneratedSeed {
+		t.Fatalf("generated seed %d is outside [0, 2^53)", first.Seed)
+	}
+
+	// Повтор с возвращённым seed воспроизводит результат
+	seed := first.Seed
+	second, err := svc.Optimize(context.Background(), testRequest(&seed))
+	if err != nil {
+		t.Fatalf("Optimize with seed: %v", err)
+	}
+	if second.Seed != seed {
+		t.Errorf("response seed = %d, want %d", second.Seed, seed)
+	}
+	if !protobuf.Equal(first.WeeklyCost, second.WeeklyCost) || len(first.Results) != len(second.Results) {
+		t.Fatalf("weekly cost %v, want %v", second.WeeklyCost, first.WeeklyCost)
+	}
+	for i := range first.Results {
+		// Время прогона от seed не зависит
+		first.Results[i].Stats.ElapsedMs, second.Results[i].Stats.ElapsedMs = 0, 0
+		if !protobuf.Equal(first.Results[i], second.Results[i]) {
+			t.Errorf("result %d differs:\n%v\n%v", i, first.Results[i], second.Results[i])
+		}
+	}
+}
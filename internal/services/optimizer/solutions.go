@@ -0,0 +1,104 @@
+package optimizer
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"log/slog"
+	"time"
+
+	protobuf "google.golang.org/protobuf/proto"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/pkg/errors"
+)
+
+func (s *Service) GetSolution(ctx context.Context, id string) (models.Solution, error) {
+	solution, err := s.storage.GetSolution(ctx, id)
+	if stderrors.Is(err, errors.ErrNotFound) {
+		return models.Solution{}, errors.NewNotFoundError("solution not found")
+	}
+	if err != nil {
+		return models.Solution{}, errors.NewErrInternal(err, "failed to load solution")
+	}
+	return solution, nil
+}
+
+func (s *Service) ListSolutions(ctx context.Context, filter models.SolutionFilter) (models.SolutionList, error) {
+	items, total, err := s.storage.ListSolutions(ctx, filter)
+	if err != nil {
+		return models.SolutionList{}, errors.NewErrInternal(err, "failed to list solutions")
+	}
+	return models.SolutionList{
+		Items:  items,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+// saveSolutionTimeout ограничивает запись решения, которая не зависит от отмены запроса.
+const saveSolutionTimeout = 10 * time.Second
+
+// saveSolution сохраняет результат оптимизации под resp.SolutionId.
+func (s *Service) saveSolution(
+	ctx context.Context,
+	req *proto.OptimizeRequest,
+	resp *proto.OptimizeResponse,
+	fingerprint string,
+	startedAt time.Time,
+) error {
+	request, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	// В параметрах ГА сохраняем фактический seed прогона, даже если клиент его не передавал
+	level1 := protobuf.CloneOf(req.GaSettingsLevel_1)
+	level1.Seed = &resp.Seed
+
+	gaSettings, err := json.Marshal(map[string]*proto.GASettings{
+		"ga_settings_level_1": level1,
+		"ga_settings_level_2": req.GaSettingsLevel_2,
+	})
+	if err != nil {
+		return err
+	}
+
+	results, err := json.Marshal(resp.Results)
+	if err != nil {
+		return err
+	}
+
+	cost := models.SolutionCost{
+		LinehaulCost: resp.WeeklyCost.GetLinehaulCost(),
+		LastMileCost: resp.WeeklyCost.GetLastMileCost(),
+		PenaltyCost:  resp.WeeklyCost.GetPenaltyCost(),
+		TotalCost:    resp.WeeklyCost.GetTotalCost(),
+	}
+
+	solution := models.Solution{
+		ID:                 resp.SolutionId,
+		Direction:          req.Direction,
+		DeliveryDays:       req.DeliveryDays,
+		Request:            request,
+		GASettings:         gaSettings,
+		DatasetFingerprint: fingerprint,
+		Results:            results,
+		Cost:               cost,
+		StartedAt:          startedAt,
+		CreatedAt:          resp.CreatedAt.AsTime(),
+	}
+
+	// Решение уже посчитано: отключение клиента не должно прерывать его сохранение
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), saveSolutionTimeout)
+	defer cancel()
+
+	if err := s.storage.InsertSolution(ctx, solution); err != nil {
+		return err
+	}
+
+	s.logger.Info("Solution saved", slog.String("solution_id", solution.ID))
+	return nil
+}
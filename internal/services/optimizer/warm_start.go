@@ -0,0 +1,90 @@
+package optimizer
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/internal/services/optimizer/ga_level1"
+	"noytech-ga-optimizer/pkg/errors"
+)
+
+// warmStart — наборы терминалов, с которых начинается ГА 1-го уровня при INIT_WARM_START.
+type warmStart struct {
+	client   [][]string            // Переданные в запросе, для всех дней
+	solution map[string][][]string // Из сохранённого решения по дням отгрузки
+	all      [][]string            // Из сохранённого решения, все дни в порядке результатов
+}
+
+// loadWarmStart собирает наборы терминалов тёплого старта из запроса и сохранённого решения.
+// Для других стратегий инициализации возвращает nil.
+func (s *Service) loadWarmStart(ctx context.Context, req *proto.OptimizeRequest) (*warmStart, error) {
+	init := req.GaSettingsLevel_1.GetInit()
+	if init.GetStrategy() != proto.InitStrategy_INIT_WARM_START {
+		return nil, nil
+	}
+
+	w := &warmStart{solution: make(map[string][][]string)}
+	for _, set := range init.TerminalSets {
+		w.client = append(w.client, set.ActiveTerminals)
+	}
+	if init.SolutionId == "" {
+		return w, nil
+	}
+
+	field := "ga_settings_level_1.init.solution_id"
+	solution, err := s.storage.GetSolution(ctx, init.SolutionId)
+	if stderrors.Is(err, errors.ErrNotFound) {
+		return nil, errors.NewErrInvalidArgumentWithDetails([]errors.ErrorDetail{{Field: field, Message: "solution not found"}})
+	}
+	if err != nil {
+		return nil, errors.NewErrInternal(err, "failed to load solution")
+	}
+	if solution.Direction != req.Direction {
+		return nil, errors.NewErrInvalidArgumentWithDetails([]errors.ErrorDetail{{
+			Field:   field,
+			Message: fmt.Sprintf("solution was computed for direction '%s'", solution.Direction),
+		}})
+	}
+
+	var results []*proto.OptimizationResult
+	if err := json.Unmarshal(solution.Results, &results); err != nil {
+		return nil, errors.NewErrInternal(err, "failed to decode solution results")
+	}
+	for _, r := range results {
+		w.solution[r.DeliveryDay] = append(w.solution[r.DeliveryDay], r.ActiveTerminals)
+		w.all = append(w.all, r.ActiveTerminals)
+	}
+	return w, nil
+}
+
+// masks возвращает маски тёплого старта для дня отгрузки: наборы из запроса и наборы сохранённого
+// решения для того же дня, а если этот день в решении не оптимизировался — наборы всех его дней.
+// Терминалы, которых больше нет в направлении, отбрасываются; пустые наборы пропускаются.
+func (w *warmStart) masks(day string, terminals []models.Terminal) [][]bool {
+	if w == nil {
+		return nil
+	}
+
+	sets := append([][]string(nil), w.client...)
+	if daySets, ok := w.solution[day]; ok {
+		sets = append(sets, daySets...)
+	} else {
+		sets = append(sets, w.all...)
+	}
+
+	masks := make([][]bool, 0, len(sets))
+	for _, set := range sets {
+		mask := ga_level1.NewMask(terminals, set)
+		for _, open := range mask {
+			if open {
+				masks = append(masks, mask)
+				break
+			}
+		}
+	}
+	return masks
+}
@@ -31,4 +31,18 @@ type Storage interface {
 	GetAllDistances(ctx context.Context) ([]models.Distance, error)
 	GetAllInterCityRates(ctx context.Context) ([]models.InterCityRate, error)
 	GetAllIntraCityRates(ctx context.Context) ([]models.IntraCityRate, error)
+
+	// Fleet
+	ReplaceVehicles(ctx context.Context, vehicles []models.Vehicle) error
+	GetAllVehicles(ctx context.Context) ([]models.Vehicle, error)
+	InsertVehicle(ctx context.Context, vehicle models.Vehicle) error
+	UpdateVehicle(ctx context.Context, vehicle models.Vehicle) error
+	DeleteVehicle(ctx context.Context, id string) error
+	GetFleetAvailability(ctx context.Context) ([]models.FleetAvailability, error)
+	ReplaceFleetAvailability(ctx context.Context, availability []models.FleetAvailability) error
+
+	// Solutions
+	InsertSolution(ctx context.Context, solution models.Solution) error
+	GetSolution(ctx context.Context, id string) (models.Solution, error)
+	ListSolutions(ctx context.Context, filter models.SolutionFilter) ([]models.Solution, int, error)
 }
@@ -2,11 +2,15 @@ package storage
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/pkg/errors"
 )
 
 type PostgresStorage struct {
@@ -26,10 +30,10 @@ func (s *PostgresStorage) BatchInsertShipments(ctx context.Context, shipments []
 	batch := &pgx.Batch{}
 	for _, sh := range shipments {
 		batch.Queue(`
-			INSERT INTO shipments (id, weight_kg, volume_m3, destination_city, date)
-			VALUES ($1, $2, $3, $4, $5)
+			INSERT INTO shipments (id, weight_kg, volume_m3, destination_city, date, pallets)
+			VALUES ($1, $2, $3, $4, $5, $6)
 			ON CONFLICT (id) DO NOTHING`,
-			sh.ID, sh.WeightKg, sh.VolumeM3, sh.DestinationCity, sh.Date)
+			sh.ID, sh.WeightKg, sh.VolumeM3, sh.DestinationCity, sh.Date, sh.Pallets)
 	}
 
 	br := s.pool.SendBatch(ctx, batch)
@@ -159,8 +163,9 @@ func (s *PostgresStorage) BatchInsertIntraCityRates(ctx context.Context, rates [
 
 func (s *PostgresStorage) GetAllShipments(ctx context.Context) ([]models.Shipment, error) {
 	rows, err := s.pool.Query(ctx, `
-		SELECT id, weight_kg, volume_m3, destination_city, date
+		SELECT id, weight_kg, volume_m3, destination_city, date, pallets
 		FROM shipments
+		ORDER BY id
 	`)
 	if err != nil {
 		return nil, err
@@ -170,7 +175,7 @@ func (s *PostgresStorage) GetAllShipments(ctx context.Context) ([]models.Shipmen
 	var shipments []models.Shipment
 	for rows.Next() {
 		var s models.Shipment
-		err = rows.Scan(&s.ID, &s.WeightKg, &s.VolumeM3, &s.DestinationCity, &s.Date)
+		err = rows.Scan(&s.ID, &s.WeightKg, &s.VolumeM3, &s.DestinationCity, &s.Date, &s.Pallets)
 		if err != nil {
 			return nil, err
 		}
@@ -183,6 +188,7 @@ func (s *PostgresStorage) GetAllTerminals(ctx context.Context) ([]models.Termina
 	rows, err := s.pool.Query(ctx, `
 		SELECT city, direction, distance_from_moscow_km
 		FROM terminals
+		ORDER BY city
 	`)
 	if err != nil {
 		return nil, err
@@ -266,3 +272,247 @@ func (s *PostgresStorage) GetAllIntraCityRates(ctx context.Context) ([]models.In
 	}
 	return rates, nil
 }
+
+// ReplaceVehicles заменяет справочник автопарка одной транзакцией: классы из vehicles добавляются
+// или обновляются, остальные удаляются вместе со своими лимитами. Лимиты оставшихся классов сохраняются.
+func (s *PostgresStorage) ReplaceVehicles(ctx context.Context, vehicles []models.Vehicle) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	ids := make([]string, len(vehicles))
+	for i, v := range vehicles {
+		ids[i] = v.ID
+		_, err := tx.Exec(ctx, `
+			INSERT INTO vehicles (id, name, capacity_tons, capacity_m3, capacity_pallets, cost_per_km, cost_per_trip)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (id) DO UPDATE
+			SET name = EXCLUDED.name, capacity_tons = EXCLUDED.capacity_tons, capacity_m3 = EXCLUDED.capacity_m3,
+				capacity_pallets = EXCLUDED.capacity_pallets, cost_per_km = EXCLUDED.cost_per_km,
+				cost_per_trip = EXCLUDED.cost_per_trip`,
+			v.ID, v.Name, v.CapacityTons, v.CapacityM3, v.CapacityPallets, v.CostPerKm, v.CostPerTrip)
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM vehicles WHERE id <> ALL($1)", ids); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *PostgresStorage) GetAllVehicles(ctx context.Context) ([]models.Vehicle, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, name, capacity_tons, capacity_m3, capacity_pallets, cost_per_km, cost_per_trip
+		FROM vehicles
+		ORDER BY capacity_tons, capacity_m3, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var vehicles []models.Vehicle
+	for rows.Next() {
+		var v models.Vehicle
+		err = rows.Scan(&v.ID, &v.Name, &v.CapacityTons, &v.CapacityM3, &v.CapacityPallets, &v.CostPerKm, &v.CostPerTrip)
+		if err != nil {
+			return nil, err
+		}
+		vehicles = append(vehicles, v)
+	}
+	return vehicles, nil
+}
+
+func (s *PostgresStorage) InsertVehicle(ctx context.Context, v models.Vehicle) error {
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO vehicles (id, name, capacity_tons, capacity_m3, capacity_pallets, cost_per_km, cost_per_trip)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO NOTHING`,
+		v.ID, v.Name, v.CapacityTons, v.CapacityM3, v.CapacityPallets, v.CostPerKm, v.CostPerTrip)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.ErrAlreadyExists
+	}
+	return nil
+}
+
+func (s *PostgresStorage) UpdateVehicle(ctx context.Context, v models.Vehicle) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE vehicles
+		SET name = $2, capacity_tons = $3, capacity_m3 = $4, capacity_pallets = $5, cost_per_km = $6, cost_per_trip = $7
+		WHERE id = $1`,
+		v.ID, v.Name, v.CapacityTons, v.CapacityM3, v.CapacityPallets, v.CostPerKm, v.CostPerTrip)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+func (s *PostgresStorage) DeleteVehicle(ctx context.Context, id string) error {
+	tag, err := s.pool.Exec(ctx, "DELETE FROM vehicles WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+func (s *PostgresStorage) GetFleetAvailability(ctx context.Context) ([]models.FleetAvailability, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT vehicle_id, delivery_day, count
+		FROM fleet_availability
+		ORDER BY vehicle_id, delivery_day
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var availability []models.FleetAvailability
+	for rows.Next() {
+		var a models.FleetAvailability
+		err = rows.Scan(&a.VehicleID, &a.DeliveryDay, &a.Count)
+		if err != nil {
+			return nil, err
+		}
+		availability = append(availability, a)
+	}
+	return availability, nil
+}
+
+// ReplaceFleetAvailability заменяет все лимиты автопарка одной транзакцией.
+func (s *PostgresStorage) ReplaceFleetAvailability(ctx context.Context, availability []models.FleetAvailability) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DELETE FROM fleet_availability"); err != nil {
+		return err
+	}
+	for _, a := range availability {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO fleet_availability (vehicle_id, delivery_day, count)
+			VALUES ($1, $2, $3)`,
+			a.VehicleID, a.DeliveryDay, a.Count)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *PostgresStorage) InsertSolution(ctx context.Context, solution models.Solution) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO solutions (
+			id, direction, delivery_days, request, ga_settings, dataset_fingerprint, results,
+			linehaul_cost, last_mile_cost, penalty_cost, total_cost, started_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		solution.ID, solution.Direction, solution.DeliveryDays,
+		solution.Request, solution.GASettings, solution.DatasetFingerprint, solution.Results,
+		solution.Cost.LinehaulCost, solution.Cost.LastMileCost, solution.Cost.PenaltyCost, solution.Cost.TotalCost,
+		solution.StartedAt, solution.CreatedAt)
+	return err
+}
+
+func (s *PostgresStorage) GetSolution(ctx context.Context, id string) (models.Solution, error) {
+	var sol models.Solution
+	err := s.pool.QueryRow(ctx, `
+		SELECT id::text, direction, delivery_days, request, ga_settings, dataset_fingerprint, results,
+			linehaul_cost, last_mile_cost, penalty_cost, total_cost, started_at, created_at
+		FROM solutions
+		WHERE id = $1
+	`, id).Scan(
+		&sol.ID, &sol.Direction, &sol.DeliveryDays, &sol.Request, &sol.GASettings, &sol.DatasetFingerprint, &sol.Results,
+		&sol.Cost.LinehaulCost, &sol.Cost.LastMileCost, &sol.Cost.PenaltyCost, &sol.Cost.TotalCost,
+		&sol.StartedAt, &sol.CreatedAt,
+	)
+	if stderrors.Is(err, pgx.ErrNoRows) {
+		return models.Solution{}, errors.ErrNotFound
+	}
+	if err != nil {
+		return models.Solution{}, err
+	}
+	return sol, nil
+}
+
+func (s *PostgresStorage) ListSolutions(ctx context.Context, filter models.SolutionFilter) ([]models.Solution, int, error) {
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+
+	if filter.Direction != "" {
+		args = append(args, filter.Direction)
+		conditions = append(conditions, fmt.Sprintf("direction = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT id::text, direction, delivery_days, dataset_fingerprint,
+			linehaul_cost, last_mile_cost, penalty_cost, total_cost, started_at, created_at,
+			COUNT(*) OVER ()
+		FROM solutions
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args))
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	solutions := make([]models.Solution, 0)
+	total := 0
+	for rows.Next() {
+		var sol models.Solution
+		err = rows.Scan(
+			&sol.ID, &sol.Direction, &sol.DeliveryDays, &sol.DatasetFingerprint,
+			&sol.Cost.LinehaulCost, &sol.Cost.LastMileCost, &sol.Cost.PenaltyCost, &sol.Cost.TotalCost,
+			&sol.StartedAt, &sol.CreatedAt, &total,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		solutions = append(solutions, sol)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if len(solutions) == 0 && filter.Offset > 0 {
+		err = s.pool.QueryRow(ctx, "SELECT COUNT(*) FROM solutions "+where, args[:len(args)-2]...).Scan(&total)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return solutions, total, nil
+}
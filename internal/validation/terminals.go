@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"fmt"
+
+	"noytech-ga-optimizer/api/proto"
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/pkg/errors"
+)
+
+// ValidateTerminalConstraints проверяет ограничения на набор терминалов по терминалам,
+// из которых выбирает ГА (с учётом направления): города из must_open и must_close должны быть
+// в этом списке, как и города наборов тёплого старта, а min_active_terminals — достижимо без терминалов из must_close.
+func ValidateTerminalConstraints(req *proto.OptimizeRequest, terminals []models.Terminal) error {
+	var validationErrors []errors.ErrorDetail
+
+	known := make(map[string]bool, len(terminals))
+	for _, t := range terminals {
+		known[t.City] = true
+	}
+
+	for i, city := range req.MustOpen {
+		if !known[city] {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   fmt.Sprintf("must_open[%d]", i),
+				Message: fmt.Sprintf("unknown terminal city '%s'", city),
+			})
+		}
+	}
+
+	closed := 0
+	for i, city := range req.MustClose {
+		if !known[city] {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   fmt.Sprintf("must_close[%d]", i),
+				Message: fmt.Sprintf("unknown terminal city '%s'", city),
+			})
+			continue
+		}
+		closed++
+	}
+
+	for i, set := range req.GaSettingsLevel_1.GetInit().GetTerminalSets() {
+		for j, city := range set.ActiveTerminals {
+			if !known[city] {
+				validationErrors = append(validationErrors, errors.ErrorDetail{
+					Field:   fmt.Sprintf("ga_settings_level_1.init.terminal_sets[%d].active_terminals[%d]", i, j),
+					Message: fmt.Sprintf("unknown terminal city '%s'", city),
+				})
+			}
+		}
+	}
+
+	if available := len(terminals) - closed; int(req.MinActiveTerminals) > available {
+		validationErrors = append(validationErrors, errors.ErrorDetail{
+			Field:   "min_active_terminals",
+			Message: fmt.Sprintf("only %d terminals can be opened", available),
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewErrInvalidArgumentWithDetails(validationErrors)
+	}
+
+	return nil
+}
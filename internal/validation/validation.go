@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/google/uuid"
+
 	"noytech-ga-optimizer/api/proto"
 	"noytech-ga-optimizer/pkg/errors"
 )
@@ -33,8 +35,16 @@ var AllowedCrossoverTypes = map[proto.CrossoverType]bool{
 }
 
 var AllowedMutationTypes = map[proto.MutationType]bool{
-	proto.MutationType_MUTATION_INVERSION: true,
-	proto.MutationType_MUTATION_SWAP:      true,
+	proto.MutationType_MUTATION_INVERSION:     true,
+	proto.MutationType_MUTATION_SWAP:          true,
+	proto.MutationType_MUTATION_BIT_FLIP:      true,
+	proto.MutationType_MUTATION_ADD_DROP_SWAP: true,
+}
+
+// Level1OnlyMutationTypes меняют число открытых терминалов и применимы только к маске терминалов 1-го уровня.
+var Level1OnlyMutationTypes = map[proto.MutationType]bool{
+	proto.MutationType_MUTATION_BIT_FLIP:      true,
+	proto.MutationType_MUTATION_ADD_DROP_SWAP: true,
 }
 
 func ValidateOptimizeRequest(req *proto.OptimizeRequest) error {
@@ -108,6 +118,74 @@ func ValidateOptimizeRequest(req *proto.OptimizeRequest) error {
 		validationErrors = append(validationErrors, gaErrs...)
 	}
 
+	// 4. ga_settings_level_2 (необязательное)
+	if req.GaSettingsLevel_2 != nil {
+		gaErrs := validateGASettings(req.GaSettingsLevel_2, "ga_settings_level_2")
+		validationErrors = append(validationErrors, gaErrs...)
+
+		if Level1OnlyMutationTypes[req.GaSettingsLevel_2.MutationType] {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   "ga_settings_level_2.mutation_type",
+				Message: fmt.Sprintf("%s is supported only in ga_settings_level_1", req.GaSettingsLevel_2.MutationType),
+			})
+		}
+
+		if req.GaSettingsLevel_2.Islands != nil {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   "ga_settings_level_2.islands",
+				Message: "island model is supported only in ga_settings_level_1",
+			})
+		}
+
+		if len(req.GaSettingsLevel_2.Objectives) > 0 {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   "ga_settings_level_2.objectives",
+				Message: "multi-objective mode is supported only in ga_settings_level_1",
+			})
+		}
+
+		if req.GaSettingsLevel_2.LocalSearch != nil {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   "ga_settings_level_2.local_search",
+				Message: "local search is supported only in ga_settings_level_1",
+			})
+		}
+
+		if req.GaSettingsLevel_2.Diversity != nil {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   "ga_settings_level_2.diversity",
+				Message: "diversity settings are supported only in ga_settings_level_1",
+			})
+		}
+
+		if req.GaSettingsLevel_2.StopConditions != nil {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   "ga_settings_level_2.stop_conditions",
+				Message: "stop conditions are supported only in ga_settings_level_1",
+			})
+		}
+
+		if req.GaSettingsLevel_2.Init != nil {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   "ga_settings_level_2.init",
+				Message: "population init strategies are supported only in ga_settings_level_1",
+			})
+		}
+
+		if req.GaSettingsLevel_2.Seed != nil {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   "ga_settings_level_2.seed",
+				Message: "seed applies to the whole run and must be set in ga_settings_level_1",
+			})
+		}
+	}
+
+	// 5. must_open, must_close, min/max_active_terminals (необязательные)
+	validationErrors = append(validationErrors, validateTerminalBounds(req)...)
+
+	// 6. algorithm (необязательное, по умолчанию — ГА)
+	validationErrors = append(validationErrors, validateAlgorithm(req)...)
+
 	if len(validationErrors) > 0 {
 		return errors.NewErrInvalidArgumentWithDetails(validationErrors)
 	}
@@ -115,6 +193,102 @@ func ValidateOptimizeRequest(req *proto.OptimizeRequest) error {
 	return nil
 }
 
+// AllowedAlgorithms — алгоритмы выбора терминалов; ALGORITHM_UNSPECIFIED означает ГА.
+var AllowedAlgorithms = map[proto.Algorithm]bool{
+	proto.Algorithm_ALGORITHM_UNSPECIFIED: true,
+	proto.Algorithm_ALGORITHM_GA:          true,
+	proto.Algorithm_ALGORITHM_EXHAUSTIVE:  true,
+	proto.Algorithm_ALGORITHM_GREEDY_ADD:  true,
+	proto.Algorithm_ALGORITHM_GREEDY_DROP: true,
+}
+
+func validateAlgorithm(req *proto.OptimizeRequest) []errors.ErrorDetail {
+	if !AllowedAlgorithms[req.Algorithm] {
+		allowed := make([]string, 0, len(AllowedAlgorithms))
+		for k := range AllowedAlgorithms {
+			if k != proto.Algorithm_ALGORITHM_UNSPECIFIED {
+				allowed = append(allowed, k.String())
+			}
+		}
+		return []errors.ErrorDetail{{
+			Field:   "algorithm",
+			Message: fmt.Sprintf("invalid value. Allowed: %s", strings.Join(allowed, ", ")),
+		}}
+	}
+
+	// Эталонные алгоритмы однокритериальные: сравнивать с ними фронт Парето не с чем
+	baseline := req.Algorithm != proto.Algorithm_ALGORITHM_UNSPECIFIED && req.Algorithm != proto.Algorithm_ALGORITHM_GA
+	if baseline && req.GaSettingsLevel_1 != nil && len(req.GaSettingsLevel_1.Objectives) > 0 {
+		return []errors.ErrorDetail{{
+			Field:   "algorithm",
+			Message: fmt.Sprintf("%s cannot be combined with ga_settings_level_1.objectives", req.Algorithm),
+		}}
+	}
+
+	return nil
+}
+
+// validateTerminalBounds проверяет, что ограничения на набор терминалов не противоречат друг другу.
+// Наличие городов в списке терминалов проверяет ValidateTerminalConstraints.
+func validateTerminalBounds(req *proto.OptimizeRequest) []errors.ErrorDetail {
+	var errs []errors.ErrorDetail
+
+	open := make(map[string]bool)
+	for i, city := range req.MustOpen {
+		field := fmt.Sprintf("must_open[%d]", i)
+		switch {
+		case city == "":
+			errs = append(errs, errors.ErrorDetail{Field: field, Message: "city cannot be empty"})
+		case open[city]:
+			errs = append(errs, errors.ErrorDetail{Field: field, Message: fmt.Sprintf("duplicate city: '%s'", city)})
+		}
+		open[city] = true
+	}
+
+	closed := make(map[string]bool)
+	for i, city := range req.MustClose {
+		field := fmt.Sprintf("must_close[%d]", i)
+		switch {
+		case city == "":
+			errs = append(errs, errors.ErrorDetail{Field: field, Message: "city cannot be empty"})
+		case closed[city]:
+			errs = append(errs, errors.ErrorDetail{Field: field, Message: fmt.Sprintf("duplicate city: '%s'", city)})
+		case open[city]:
+			errs = append(errs, errors.ErrorDetail{Field: field, Message: fmt.Sprintf("city '%s' is also in must_open", city)})
+		}
+		closed[city] = true
+	}
+
+	if req.MinActiveTerminals < 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   "min_active_terminals",
+			Message: "must not be negative",
+		})
+	}
+
+	if req.MaxActiveTerminals < 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   "max_active_terminals",
+			Message: "must not be negative",
+		})
+	} else if req.MaxActiveTerminals > 0 {
+		if req.MinActiveTerminals > req.MaxActiveTerminals {
+			errs = append(errs, errors.ErrorDetail{
+				Field:   "min_active_terminals",
+				Message: "must not be greater than max_active_terminals",
+			})
+		}
+		if len(open) > int(req.MaxActiveTerminals) {
+			errs = append(errs, errors.ErrorDetail{
+				Field:   "max_active_terminals",
+				Message: fmt.Sprintf("must_open lists %d terminals, more than max_active_terminals", len(open)),
+			})
+		}
+	}
+
+	return errs
+}
+
 func validateGASettings(settings *proto.GASettings, prefix string) []errors.ErrorDetail {
 	var errs []errors.ErrorDetail
 
@@ -142,6 +316,19 @@ func validateGASettings(settings *proto.GASettings, prefix string) []errors.Erro
 		})
 	}
 
+	// elite_count
+	if settings.EliteCount < 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".elite_count",
+			Message: "must not be negative",
+		})
+	} else if settings.NumIndividuals > 0 && settings.EliteCount >= settings.NumIndividuals {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".elite_count",
+			Message: "must be less than num_individuals",
+		})
+	}
+
 	// selection_type
 	if settings.SelectionType == proto.SelectionType_SELECTION_UNSPECIFIED {
 		allowed := strings.Join(allowedEnumValuesSelection(), ", ")
@@ -187,6 +374,531 @@ func validateGASettings(settings *proto.GASettings, prefix string) []errors.Erro
 		})
 	}
 
+	// mutation_rate, crossover_rate
+	if settings.MutationRate != nil && (*settings.MutationRate < 0 || *settings.MutationRate > 1) {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".mutation_rate",
+			Message: "must be between 0 and 1",
+		})
+	}
+	if settings.CrossoverRate != nil && (*settings.CrossoverRate < 0 || *settings.CrossoverRate > 1) {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".crossover_rate",
+			Message: "must be between 0 and 1",
+		})
+	}
+
+	if settings.MaxDurationMs < 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".max_duration_ms",
+			Message: "must not be negative",
+		})
+	}
+
+	// tournament_size (0 — по умолчанию)
+	if settings.TournamentSize < 0 || settings.TournamentSize > settings.NumIndividuals {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".tournament_size",
+			Message: "must be between 0 and num_individuals",
+		})
+	}
+
+	// islands (необязательное)
+	if settings.Islands != nil {
+		errs = append(errs, validateIslands(settings.Islands, settings.NumIndividuals, prefix+".islands")...)
+	}
+
+	// objectives (необязательное): многокритериальный режим, не меньше двух разных целей
+	if len(settings.Objectives) > 0 {
+		errs = append(errs, validateObjectives(settings, prefix)...)
+	}
+
+	// local_search (необязательное)
+	if settings.LocalSearch != nil {
+		errs = append(errs, validateLocalSearch(settings, prefix+".local_search")...)
+	}
+
+	// init (необязательное)
+	if settings.Init != nil {
+		errs = append(errs, validateInit(settings.Init, prefix+".init")...)
+	}
+
+	// stop_conditions (необязательное)
+	if settings.StopConditions != nil {
+		errs = append(errs, validateStopConditions(settings, prefix+".stop_conditions")...)
+	}
+
+	// diversity (необязательное)
+	if settings.Diversity != nil {
+		errs = append(errs, validateDiversity(settings, prefix+".diversity")...)
+	}
+
+	// scaling (необязательное)
+	if settings.Scaling != nil {
+		errs = append(errs, validateScaling(settings, prefix+".scaling")...)
+	}
+
+	// NSGA-II выбирает родителей бинарным турниром по рангу фронта и crowding distance
+	if len(settings.Objectives) > 0 && settings.TournamentSize != 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".tournament_size",
+			Message: "tournament_size cannot be combined with multi-objective mode",
+		})
+	}
+
+	return errs
+}
+
+func validateStopConditions(settings *proto.GASettings, prefix string) []errors.ErrorDetail {
+	var errs []errors.ErrorDetail
+	c := settings.StopConditions
+
+	if c.TargetCost < 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".target_cost",
+			Message: "must not be negative",
+		})
+	}
+
+	// min_relative_improvement и improvement_window задаются вместе
+	if c.MinRelativeImprovement < 0 || c.MinRelativeImprovement > 1 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".min_relative_improvement",
+			Message: "must be between 0 and 1",
+		})
+	}
+	if c.ImprovementWindow < 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".improvement_window",
+			Message: "must not be negative",
+		})
+	}
+	if (c.MinRelativeImprovement > 0) != (c.ImprovementWindow > 0) {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".improvement_window",
+			Message: "min_relative_improvement and improvement_window must be set together",
+		})
+	}
+
+	if c.MinDiversity < 0 || c.MinDiversity > 1 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".min_diversity",
+			Message: "must be between 0 and 1",
+		})
+	}
+
+	if c.MaxEvaluations < 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".max_evaluations",
+			Message: "must not be negative",
+		})
+	}
+
+	// NSGA-II останавливается только по num_generations, stopping_criterion и max_duration_ms
+	if len(settings.Objectives) > 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix,
+			Message: "stop conditions cannot be combined with multi-objective mode",
+		})
+	}
+
+	return errs
+}
+
+// AllowedNichingMethods — методы ниш; NICHING_METHOD_UNSPECIFIED означает работу без ниш.
+var AllowedNichingMethods = map[proto.NichingMethod]bool{
+	proto.NichingMethod_NICHING_METHOD_UNSPECIFIED: true,
+	proto.NichingMethod_NICHING_FITNESS_SHARING:    true,
+	proto.NichingMethod_NICHING_CROWDING:           true,
+}
+
+func validateDiversity(settings *proto.GASettings, prefix string) []errors.ErrorDetail {
+	var errs []errors.ErrorDetail
+	d := settings.Diversity
+
+	// niching, sharing_radius
+	if !AllowedNichingMethods[d.Niching] {
+		allowed := make([]string, 0, len(AllowedNichingMethods))
+		for k := range AllowedNichingMethods {
+			if k != proto.NichingMethod_NICHING_METHOD_UNSPECIFIED {
+				allowed = append(allowed, k.String())
+			}
+		}
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".niching",
+			Message: fmt.Sprintf("invalid value. Allowed: %s", strings.Join(allowed, ", ")),
+		})
+	}
+	if d.Niching == proto.NichingMethod_NICHING_FITNESS_SHARING {
+		if d.SharingRadius <= 0 || d.SharingRadius > 1 {
+			errs = append(errs, errors.ErrorDetail{
+				Field:   prefix + ".sharing_radius",
+				Message: "must be greater than 0 and not greater than 1",
+			})
+		}
+	} else if d.SharingRadius != 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".sharing_radius",
+			Message: "is used only with NICHING_FITNESS_SHARING",
+		})
+	}
+
+	// immigrant_rate и immigrant_threshold задаются вместе
+	if d.ImmigrantRate < 0 || d.ImmigrantRate >= 1 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".immigrant_rate",
+			Message: "must be at least 0 and less than 1",
+		})
+	}
+	if d.ImmigrantThreshold < 0 || d.ImmigrantThreshold > 1 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".immigrant_threshold",
+			Message: "must be between 0 and 1",
+		})
+	}
+	if (d.ImmigrantRate > 0) != (d.ImmigrantThreshold > 0) {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".immigrant_threshold",
+			Message: "immigrant_rate and immigrant_threshold must be set together",
+		})
+	}
+
+	// NSGA-II сохраняет разнообразие фронта по crowding distance
+	if len(settings.Objectives) > 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix,
+			Message: "diversity settings cannot be combined with multi-objective mode",
+		})
+	}
+
+	return errs
+}
+
+var AllowedScalingMethods = map[proto.ScalingMethod]bool{
+	proto.ScalingMethod_SCALING_LINEAR:           true,
+	proto.ScalingMethod_SCALING_SIGMA_TRUNCATION: true,
+	proto.ScalingMethod_SCALING_BOLTZMANN:        true,
+}
+
+func validateScaling(settings *proto.GASettings, prefix string) []errors.ErrorDetail {
+	var errs []errors.ErrorDetail
+	sc := settings.Scaling
+
+	// method
+	if !AllowedScalingMethods[sc.Method] {
+		allowed := make([]string, 0, len(AllowedScalingMethods))
+		for k := range AllowedScalingMethods {
+			allowed = append(allowed, k.String())
+		}
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".method",
+			Message: fmt.Sprintf("field is required. Allowed values: %s", strings.Join(allowed, ", ")),
+		})
+	}
+
+	// Параметр задаётся только для своего метода; 0 — значение по умолчанию
+	if sc.LinearPressure != 0 && sc.Method != proto.ScalingMethod_SCALING_LINEAR {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".linear_pressure",
+			Message: "is used only with SCALING_LINEAR",
+		})
+	} else if sc.LinearPressure != 0 && (sc.LinearPressure < 1 || sc.LinearPressure > 10) {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".linear_pressure",
+			Message: "must be between 1 and 10",
+		})
+	}
+	if sc.SigmaC != 0 && sc.Method != proto.ScalingMethod_SCALING_SIGMA_TRUNCATION {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".sigma_c",
+			Message: "is used only with SCALING_SIGMA_TRUNCATION",
+		})
+	} else if sc.SigmaC < 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".sigma_c",
+			Message: "must be greater than 0",
+		})
+	}
+	if sc.Temperature != 0 && sc.Method != proto.ScalingMethod_SCALING_BOLTZMANN {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".temperature",
+			Message: "is used only with SCALING_BOLTZMANN",
+		})
+	} else if sc.Temperature < 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".temperature",
+			Message: "must be greater than 0",
+		})
+	}
+
+	// NSGA-II отбирает родителей по рангу фронта, а не по стоимости: масштабировать нечего
+	if len(settings.Objectives) > 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix,
+			Message: "fitness scaling cannot be combined with multi-objective mode",
+		})
+	}
+
+	return errs
+}
+
+var AllowedInitStrategies = map[proto.InitStrategy]bool{
+	proto.InitStrategy_INIT_RANDOM:          true,
+	proto.InitStrategy_INIT_GREEDY:          true,
+	proto.InitStrategy_INIT_DEMAND_WEIGHTED: true,
+	proto.InitStrategy_INIT_WARM_START:      true,
+}
+
+// validateInit проверяет стратегию начальной популяции. Города наборов тёплого старта
+// проверяет ValidateTerminalConstraints, наличие решения solution_id — сервис.
+func validateInit(init *proto.PopulationInit, prefix string) []errors.ErrorDetail {
+	var errs []errors.ErrorDetail
+
+	// strategy
+	if !AllowedInitStrategies[init.Strategy] {
+		allowed := make([]string, 0, len(AllowedInitStrategies))
+		for k := range AllowedInitStrategies {
+			allowed = append(allowed, k.String())
+		}
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".strategy",
+			Message: fmt.Sprintf("field is required. Allowed values: %s", strings.Join(allowed, ", ")),
+		})
+	}
+
+	// density
+	if init.Density < 0 || init.Density > 1 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".density",
+			Message: "must be between 0 and 1",
+		})
+	}
+
+	// solution_id, terminal_sets: только для тёплого старта и хотя бы одно из них
+	if init.Strategy == proto.InitStrategy_INIT_WARM_START {
+		if init.SolutionId == "" && len(init.TerminalSets) == 0 {
+			errs = append(errs, errors.ErrorDetail{
+				Field:   prefix + ".solution_id",
+				Message: "solution_id or terminal_sets is required for INIT_WARM_START",
+			})
+		}
+		if init.SolutionId != "" {
+			if _, err := uuid.Parse(init.SolutionId); err != nil {
+				errs = append(errs, errors.ErrorDetail{
+					Field:   prefix + ".solution_id",
+					Message: "must be a valid UUID",
+				})
+			}
+		}
+		for i, set := range init.TerminalSets {
+			if len(set.GetActiveTerminals()) == 0 {
+				errs = append(errs, errors.ErrorDetail{
+					Field:   fmt.Sprintf("%s.terminal_sets[%d].active_terminals", prefix, i),
+					Message: "must contain at least one city",
+				})
+			}
+		}
+	} else if init.SolutionId != "" || len(init.TerminalSets) > 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".strategy",
+			Message: "solution_id and terminal_sets are used only with INIT_WARM_START",
+		})
+	}
+
+	return errs
+}
+
+var AllowedLocalSearchStrategies = map[proto.LocalSearchStrategy]bool{
+	proto.LocalSearchStrategy_LOCAL_SEARCH_FIRST_IMPROVEMENT: true,
+	proto.LocalSearchStrategy_LOCAL_SEARCH_BEST_IMPROVEMENT:  true,
+}
+
+var AllowedLocalSearchScopes = map[proto.LocalSearchScope]bool{
+	proto.LocalSearchScope_LOCAL_SEARCH_ELITE:      true,
+	proto.LocalSearchScope_LOCAL_SEARCH_FINAL_BEST: true,
+}
+
+func validateLocalSearch(settings *proto.GASettings, prefix string) []errors.ErrorDetail {
+	var errs []errors.ErrorDetail
+	ls := settings.LocalSearch
+
+	if !AllowedLocalSearchStrategies[ls.Strategy] {
+		allowed := make([]string, 0, len(AllowedLocalSearchStrategies))
+		for k := range AllowedLocalSearchStrategies {
+			allowed = append(allowed, k.String())
+		}
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".strategy",
+			Message: fmt.Sprintf("field is required. Allowed values: %s", strings.Join(allowed, ", ")),
+		})
+	}
+
+	if !AllowedLocalSearchScopes[ls.Scope] {
+		allowed := make([]string, 0, len(AllowedLocalSearchScopes))
+		for k := range AllowedLocalSearchScopes {
+			allowed = append(allowed, k.String())
+		}
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".scope",
+			Message: fmt.Sprintf("field is required. Allowed values: %s", strings.Join(allowed, ", ")),
+		})
+	}
+
+	if ls.MaxEvaluations < 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".max_evaluations",
+			Message: "must not be negative",
+		})
+	}
+	if ls.MaxDurationMs < 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".max_duration_ms",
+			Message: "must not be negative",
+		})
+	}
+	if ls.MaxEvaluations == 0 && ls.MaxDurationMs == 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix,
+			Message: "max_evaluations or max_duration_ms must be set",
+		})
+	}
+
+	if len(settings.Objectives) > 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix,
+			Message: "local search cannot be combined with multi-objective mode",
+		})
+	}
+
+	return errs
+}
+
+var AllowedObjectives = map[proto.Objective]bool{
+	proto.Objective_OBJECTIVE_TOTAL_COST:       true,
+	proto.Objective_OBJECTIVE_ACTIVE_TERMINALS: true,
+	proto.Objective_OBJECTIVE_AVG_DISTANCE:     true,
+}
+
+func validateObjectives(settings *proto.GASettings, prefix string) []errors.ErrorDetail {
+	var errs []errors.ErrorDetail
+
+	if len(settings.Objectives) < 2 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".objectives",
+			Message: "at least 2 objectives are required for multi-objective mode",
+		})
+	}
+
+	seen := make(map[proto.Objective]bool)
+	for i, o := range settings.Objectives {
+		field := fmt.Sprintf("%s.objectives[%d]", prefix, i)
+		if !AllowedObjectives[o] {
+			allowed := make([]string, 0, len(AllowedObjectives))
+			for k := range AllowedObjectives {
+				allowed = append(allowed, k.String())
+			}
+			errs = append(errs, errors.ErrorDetail{
+				Field:   field,
+				Message: fmt.Sprintf("invalid value. Allowed: %s", strings.Join(allowed, ", ")),
+			})
+		} else if seen[o] {
+			errs = append(errs, errors.ErrorDetail{
+				Field:   field,
+				Message: fmt.Sprintf("duplicate objective: %s", o),
+			})
+		}
+		seen[o] = true
+	}
+
+	if settings.Islands != nil {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".islands",
+			Message: "island model cannot be combined with multi-objective mode",
+		})
+	}
+
+	return errs
+}
+
+var AllowedMigrationTopologies = map[proto.MigrationTopology]bool{
+	proto.MigrationTopology_TOPOLOGY_RING:            true,
+	proto.MigrationTopology_TOPOLOGY_FULLY_CONNECTED: true,
+}
+
+func validateIslands(islands *proto.IslandSettings, numIndividuals int32, prefix string) []errors.ErrorDetail {
+	var errs []errors.ErrorDetail
+
+	// count
+	if islands.Count < 2 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".count",
+			Message: "must be at least 2",
+		})
+	}
+
+	// migration_interval
+	if islands.MigrationInterval <= 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".migration_interval",
+			Message: "must be greater than 0",
+		})
+	}
+
+	// topology
+	if !AllowedMigrationTopologies[islands.Topology] {
+		allowed := make([]string, 0, len(AllowedMigrationTopologies))
+		for k := range AllowedMigrationTopologies {
+			allowed = append(allowed, k.String())
+		}
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".topology",
+			Message: fmt.Sprintf("field is required. Allowed values: %s", strings.Join(allowed, ", ")),
+		})
+	}
+
+	// migrant_count: мигранты не должны вытеснять лучшую особь острова-получателя
+	received := islands.MigrantCount
+	if islands.Topology == proto.MigrationTopology_TOPOLOGY_FULLY_CONNECTED && islands.Count > 1 {
+		received *= islands.Count - 1
+	}
+	if islands.MigrantCount <= 0 {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".migrant_count",
+			Message: "must be greater than 0",
+		})
+	} else if numIndividuals > 0 && received >= numIndividuals {
+		errs = append(errs, errors.ErrorDetail{
+			Field:   prefix + ".migrant_count",
+			Message: fmt.Sprintf("island receives %d migrants, must be less than num_individuals", received),
+		})
+	}
+
+	// operators: неуказанный оператор наследуется из GASettings
+	for i, ops := range islands.Operators {
+		field := fmt.Sprintf("%s.operators[%d]", prefix, i)
+		if ops == nil {
+			continue
+		}
+		if ops.SelectionType != proto.SelectionType_SELECTION_UNSPECIFIED && !AllowedSelectionTypes[ops.SelectionType] {
+			errs = append(errs, errors.ErrorDetail{
+				Field:   field + ".selection_type",
+				Message: fmt.Sprintf("invalid value. Allowed: %s", strings.Join(allowedEnumValuesSelection(), ", ")),
+			})
+		}
+		if ops.CrossoverType != proto.CrossoverType_CROSSOVER_UNSPECIFIED && !AllowedCrossoverTypes[ops.CrossoverType] {
+			errs = append(errs, errors.ErrorDetail{
+				Field:   field + ".crossover_type",
+				Message: fmt.Sprintf("invalid value. Allowed: %s", strings.Join(allowedEnumValuesCrossover(), ", ")),
+			})
+		}
+		if ops.MutationType != proto.MutationType_MUTATION_UNSPECIFIED && !AllowedMutationTypes[ops.MutationType] {
+			errs = append(errs, errors.ErrorDetail{
+				Field:   field + ".mutation_type",
+				Message: fmt.Sprintf("invalid value. Allowed: %s", strings.Join(allowedEnumValuesMutation(), ", ")),
+			})
+		}
+	}
+
 	return errs
 }
 
@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"noytech-ga-optimizer/internal/models"
+	"noytech-ga-optimizer/pkg/errors"
+)
+
+func ValidateVehicle(v *models.Vehicle) error {
+	if v == nil {
+		return errors.NewErrInvalidArgument(nil, "request body is required")
+	}
+
+	var validationErrors []errors.ErrorDetail
+
+	if v.ID == "" {
+		validationErrors = append(validationErrors, errors.ErrorDetail{
+			Field:   "id",
+			Message: "field is required",
+		})
+	}
+	if v.CapacityTons <= 0 {
+		validationErrors = append(validationErrors, errors.ErrorDetail{
+			Field:   "capacity_tons",
+			Message: "must be greater than 0",
+		})
+	}
+	if v.CapacityM3 <= 0 {
+		validationErrors = append(validationErrors, errors.ErrorDetail{
+			Field:   "capacity_m3",
+			Message: "must be greater than 0",
+		})
+	}
+	if v.CapacityPallets < 0 {
+		validationErrors = append(validationErrors, errors.ErrorDetail{
+			Field:   "capacity_pallets",
+			Message: "must not be negative",
+		})
+	}
+	if v.CostPerKm < 0 {
+		validationErrors = append(validationErrors, errors.ErrorDetail{
+			Field:   "cost_per_km",
+			Message: "must not be negative",
+		})
+	}
+	if v.CostPerTrip < 0 {
+		validationErrors = append(validationErrors, errors.ErrorDetail{
+			Field:   "cost_per_trip",
+			Message: "must not be negative",
+		})
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewErrInvalidArgumentWithDetails(validationErrors)
+	}
+
+	return nil
+}
+
+func ValidateFleetAvailability(availability []models.FleetAvailability) error {
+	var validationErrors []errors.ErrorDetail
+
+	seen := make(map[string]bool)
+	for i, a := range availability {
+		prefix := fmt.Sprintf("[%d]", i)
+		if a.VehicleID == "" {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   prefix + ".vehicle_id",
+				Message: "field is required",
+			})
+		}
+		if !AllowedDays[a.DeliveryDay] {
+			allowed := strings.Join(allowedKeys(AllowedDays), ", ")
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   prefix + ".delivery_day",
+				Message: fmt.Sprintf("invalid day '%s'. Allowed: %s", a.DeliveryDay, allowed),
+			})
+		}
+		if a.Count < 0 {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   prefix + ".count",
+				Message: "must not be negative",
+			})
+		}
+
+		key := a.VehicleID + "|" + a.DeliveryDay
+		if seen[key] {
+			validationErrors = append(validationErrors, errors.ErrorDetail{
+				Field:   prefix,
+				Message: fmt.Sprintf("duplicate entry for vehicle '%s' on '%s'", a.VehicleID, a.DeliveryDay),
+			})
+		}
+		seen[key] = true
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.NewErrInvalidArgumentWithDetails(validationErrors)
+	}
+
+	return nil
+}
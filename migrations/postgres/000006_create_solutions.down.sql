@@ -0,0 +1,2 @@
+-- Откат таблицы solutions
+DROP TABLE IF EXISTS solutions;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- Сохранённые результаты оптимизации
+CREATE TABLE solutions (
+    id UUID PRIMARY KEY,
+    direction TEXT NOT NULL DEFAULT '',
+    delivery_days TEXT[] NOT NULL,
+    request JSONB NOT NULL,
+    ga_settings JSONB NOT NULL,
+    dataset_fingerprint TEXT NOT NULL,
+    results JSONB NOT NULL,
+    -- Штраф за решение без терминалов или невыполнимый план — 1e12 и больше за день
+    linehaul_cost DOUBLE PRECISION NOT NULL,
+    last_mile_cost DOUBLE PRECISION NOT NULL,
+    penalty_cost DOUBLE PRECISION NOT NULL,
+    total_cost DOUBLE PRECISION NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_solutions_direction ON solutions(direction);
+CREATE INDEX idx_solutions_created_at ON solutions(created_at DESC);
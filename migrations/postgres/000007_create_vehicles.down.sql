@@ -0,0 +1,2 @@
+-- Откат таблицы vehicles
+DROP TABLE IF EXISTS vehicles;
\ No newline at end of file
@@ -0,0 +1,18 @@
+-- Справочник автопарка (классы ТС)
+CREATE TABLE vehicles (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL DEFAULT '',
+    capacity_tons NUMERIC(6,2) NOT NULL CHECK (capacity_tons > 0),
+    capacity_m3 NUMERIC(6,2) NOT NULL CHECK (capacity_m3 > 0),
+    capacity_pallets INTEGER NOT NULL DEFAULT 0 CHECK (capacity_pallets >= 0),
+    cost_per_km NUMERIC(10,2) NOT NULL DEFAULT 0 CHECK (cost_per_km >= 0),
+    cost_per_trip NUMERIC(12,2) NOT NULL DEFAULT 0 CHECK (cost_per_trip >= 0)
+);
+
+-- Классы ТС, которые раньше были зашиты в код
+INSERT INTO vehicles (id, name, capacity_tons, capacity_m3, capacity_pallets) VALUES
+    ('1.5t_10m3', '1.5т / 10м3', 1.5, 10, 4),
+    ('3t_20m3', '3т / 20м3', 3, 20, 8),
+    ('5t_36m3', '5т / 36м3', 5, 36, 15),
+    ('10t_45m3', '10т / 45м3', 10, 45, 18),
+    ('20t_86m3', '20т / 86м3', 20, 86, 33);
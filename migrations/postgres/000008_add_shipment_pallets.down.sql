@@ -0,0 +1,2 @@
+-- Откат колонки pallets
+ALTER TABLE shipments DROP COLUMN IF EXISTS pallets;
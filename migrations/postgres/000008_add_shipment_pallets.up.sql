@@ -0,0 +1,2 @@
+-- Паллетомест у груза (0 — не указано, вместимость ТС в паллетах к грузу не применяется)
+ALTER TABLE shipments ADD COLUMN pallets INTEGER NOT NULL DEFAULT 0 CHECK (pallets >= 0);
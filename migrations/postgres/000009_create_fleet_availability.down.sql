@@ -0,0 +1,2 @@
+-- Откат таблицы fleet_availability
+DROP TABLE IF EXISTS fleet_availability;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- Доступность ТС по классам и дням отгрузки (класс без записи на день — без ограничений)
+CREATE TABLE fleet_availability (
+    vehicle_id TEXT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    delivery_day TEXT NOT NULL CHECK (delivery_day IN ('mon', 'tue', 'wed', 'thu', 'fri', 'sat', 'sun')),
+    count INTEGER NOT NULL CHECK (count >= 0),
+    PRIMARY KEY (vehicle_id, delivery_day)
+);
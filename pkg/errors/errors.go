@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -27,6 +28,44 @@ func (e *ErrorResponse) Error() string {
 	return e.Message
 }
 
+func (e *ErrorResponse) GRPCStatus() *status.Status {
+	st := status.New(grpcCodeFromHTTPStatus(e.Status), e.Message)
+	if len(e.Details) == 0 {
+		return st
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(e.Details))
+	for i, d := range e.Details {
+		violations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       d.Field,
+			Description: d.Message,
+		}
+	}
+
+	withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+func grpcCodeFromHTTPStatus(statusCode int) codes.Code {
+	switch statusCode {
+	case 400:
+		return codes.InvalidArgument
+	case 404:
+		return codes.NotFound
+	case 409:
+		return codes.AlreadyExists
+	case 422:
+		return codes.FailedPrecondition
+	case 503:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
 func NewErrorResponse(statusCode int, message string, details []ErrorDetail) *ErrorResponse {
 	return &ErrorResponse{
 		Status:       statusCode,
@@ -135,6 +174,18 @@ func NewUnprocessableEntityError(message string) *ErrorResponse {
 	return NewErrorResponse(422, message, nil)
 }
 
+func NewNotFoundError(message string) *ErrorResponse {
+	return NewErrorResponse(404, message, nil)
+}
+
+func NewConflictError(message string) *ErrorResponse {
+	return NewErrorResponse(409, message, nil)
+}
+
+func NewServiceUnavailableError(message string) *ErrorResponse {
+	return NewErrorResponse(503, message, nil)
+}
+
 func NewInternalServerError(message string) *ErrorResponse {
 	return NewErrorResponse(500, message, nil)
 }
@@ -195,6 +246,22 @@ func NewErrInvalidArgumentWithDetails(details []ErrorDetail) *ErrorResponse {
 	}
 }
 
+type ErrOptimizationFailed struct {
+	err error
+}
+
+func (e *ErrOptimizationFailed) Error() string {
+	return fmt.Sprintf("optimization failed: %v", e.err)
+}
+
+func (e *ErrOptimizationFailed) Unwrap() error {
+	return e.err
+}
+
+func (e *ErrOptimizationFailed) GRPCStatus() *status.Status {
+	return status.New(codes.Internal, e.Error())
+}
+
 func NewErrOptimizationFailed(format string, args ...interface{}) error {
-	return fmt.Errorf("optimization failed: "+format, args...)
+	return &ErrOptimizationFailed{err: fmt.Errorf(format, args...)}
 }